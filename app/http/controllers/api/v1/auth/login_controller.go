@@ -0,0 +1,35 @@
+package auth
+
+import (
+	"github.com/gin-gonic/gin"
+	v1 "gohub/app/http/controllers/api/v1"
+	"gohub/app/requests"
+	"gohub/pkg/auth"
+	"gohub/pkg/jwt"
+	"gohub/pkg/response"
+)
+
+// LoginController 用户控制器
+type LoginController struct {
+	v1.BaseAPIController
+}
+
+// LoginByPassword 多种方法登录，支持手机号、email 和用户名
+func (lc *LoginController) LoginByPassword(c *gin.Context) {
+	request := requests.LoginByPasswordRequest{}
+	if ok := requests.Validate(c, &request, requests.ValidateLoginByPassword); !ok {
+		return
+	}
+
+	userModel, err := auth.Attempt(request.LoginID, request.Password)
+	if err != nil {
+		// 失败，显示错误提示，不区分账号不存在和密码错误
+		response.Unauthorized(c, "auth.login_failed")
+		return
+	}
+
+	token := jwt.NewJWT().IssueToken(userModel.GetStringID(), userModel.Name)
+	response.JSON(c, gin.H{
+		"token": token,
+	})
+}
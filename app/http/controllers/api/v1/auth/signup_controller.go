@@ -5,6 +5,7 @@ import (
 	v1 "gohub/app/http/controllers/api/v1"
 	"gohub/app/models/user"
 	"gohub/app/requests"
+	"gohub/pkg/jwt"
 	"gohub/pkg/response"
 )
 
@@ -41,3 +42,29 @@ func (sc *SignupController) IsEmailExist(c *gin.Context) {
 		"exist": user.IsEmailExist(request.Email),
 	})
 }
+
+// SignupUsingPhone 使用手机和验证码进行注册
+func (sc *SignupController) SignupUsingPhone(c *gin.Context) {
+	request := requests.SignupUsingPhoneRequest{}
+	if ok := requests.Validate(c, &request, requests.ValidateSignupUsingPhone); !ok {
+		return
+	}
+
+	userModel := user.User{
+		Name:     request.Name,
+		Phone:    request.Phone,
+		Password: request.Password,
+	}
+	userModel.Create()
+
+	if userModel.ID == 0 {
+		response.Abort500(c, "auth.signup_failed")
+		return
+	}
+
+	token := jwt.NewJWT().IssueToken(userModel.GetStringID(), userModel.Name)
+	response.CreatedJSON(c, gin.H{
+		"token": token,
+		"data":  userModel,
+	})
+}
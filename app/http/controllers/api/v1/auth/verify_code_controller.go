@@ -3,9 +3,11 @@ package auth
 import (
 	"github.com/gin-gonic/gin"
 	v1 "gohub/app/http/controllers/api/v1"
+	"gohub/app/requests"
 	"gohub/pkg/captcha"
 	"gohub/pkg/logger"
 	"gohub/pkg/response"
+	"gohub/pkg/verifycode"
 )
 
 // VerifyController用户控制器
@@ -27,3 +29,19 @@ func (vc *VerifyCodeController) ShowCaptcha(c *gin.Context) {
 		"captcha_image": b64s,
 	})
 }
+
+// SendUsingPhone 发送手机验证码
+func (vc *VerifyCodeController) SendUsingPhone(c *gin.Context) {
+	// 验证表单
+	request := requests.VerifyCodePhoneRequest{}
+	if ok := requests.Validate(c, &request, requests.ValidateVerifyCodePhone); !ok {
+		return
+	}
+
+	// 发送 SMS
+	if ok := verifycode.NewVerifyCode().SendSMS(request.Phone); !ok {
+		response.Abort500(c, "verify_code.send_failed")
+	} else {
+		response.Success(c)
+	}
+}
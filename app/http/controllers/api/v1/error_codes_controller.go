@@ -0,0 +1,28 @@
+package v1
+
+import (
+	"github.com/gin-gonic/gin"
+	"gohub/pkg/errcode"
+	"gohub/pkg/i18n"
+	"gohub/pkg/response"
+)
+
+// ErrorCodesController 错误码控制器
+type ErrorCodesController struct {
+	BaseAPIController
+}
+
+// Index 列出所有错误码，供客户端对照，message 使用当前请求的语言
+func (ctrl *ErrorCodesController) Index(c *gin.Context) {
+	codes := errcode.All()
+	data := make([]gin.H, 0, len(codes))
+	for _, code := range codes {
+		data = append(data, gin.H{
+			"code":    code.Code,
+			"key":     code.Key,
+			"status":  code.Status,
+			"message": i18n.Trans(c, code.MessageKey),
+		})
+	}
+	response.Data(c, data)
+}
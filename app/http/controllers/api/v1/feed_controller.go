@@ -0,0 +1,19 @@
+package v1
+
+import (
+	"github.com/gin-gonic/gin"
+	"gohub/app/models/topic"
+	"gohub/pkg/auth"
+	"gohub/pkg/response"
+)
+
+// FeedController 动态控制器
+type FeedController struct {
+	BaseAPIController
+}
+
+// Index 当前用户关注的人最近发布的话题
+func (ctrl *FeedController) Index(c *gin.Context) {
+	data, pager := topic.PaginateFeed(c, auth.CurrentUID(c), 10)
+	response.Paginated(c, data, pager)
+}
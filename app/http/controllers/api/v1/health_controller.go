@@ -0,0 +1,37 @@
+package v1
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gohub/pkg/config"
+	"gohub/pkg/health"
+)
+
+// HealthController 存活与就绪探针
+type HealthController struct {
+	BaseAPIController
+}
+
+// Liveness 进程存活即返回 200，不检查任何依赖
+func (ctrl *HealthController) Liveness(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{
+		"status": "ok",
+	})
+}
+
+// Readiness 检查数据库、Redis 等依赖，关键依赖不可用时返回 503
+func (ctrl *HealthController) Readiness(c *gin.Context) {
+	timeout := time.Duration(config.GetInt64("health.timeout")) * time.Millisecond
+	checks, ready := health.Check(c.Request.Context(), timeout)
+
+	status, code := "ok", http.StatusOK
+	if !ready {
+		status, code = "unavailable", http.StatusServiceUnavailable
+	}
+	c.JSON(code, gin.H{
+		"status": status,
+		"checks": checks,
+	})
+}
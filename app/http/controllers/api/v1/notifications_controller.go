@@ -0,0 +1,44 @@
+package v1
+
+import (
+	"github.com/gin-gonic/gin"
+	"gohub/app/models/notification"
+	"gohub/pkg/auth"
+	"gohub/pkg/response"
+)
+
+// NotificationsController 站内通知控制器
+type NotificationsController struct {
+	BaseAPIController
+}
+
+// Index 当前用户的通知列表
+func (ctrl *NotificationsController) Index(c *gin.Context) {
+	data, pager := notification.Paginate(c, auth.CurrentUID(c), 10)
+	response.Paginated(c, data, pager)
+}
+
+// UnreadCount 当前用户的未读通知数
+func (ctrl *NotificationsController) UnreadCount(c *gin.Context) {
+	response.Data(c, gin.H{
+		"unread_count": notification.UnreadCount(auth.CurrentUID(c)),
+	})
+}
+
+// MarkRead 将一条通知标记为已读，已读的通知重复标记不报错
+func (ctrl *NotificationsController) MarkRead(c *gin.Context) {
+	notificationModel := notification.Get(auth.CurrentUID(c), c.Param("id"))
+	if notificationModel.ID == 0 {
+		response.Abort404(c)
+		return
+	}
+
+	notification.MarkRead(notificationModel.UserID, notificationModel.GetStringID())
+	response.Success(c)
+}
+
+// MarkAllRead 将所有通知标记为已读
+func (ctrl *NotificationsController) MarkAllRead(c *gin.Context) {
+	notification.MarkAllRead(auth.CurrentUID(c))
+	response.Success(c)
+}
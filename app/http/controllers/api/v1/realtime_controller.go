@@ -0,0 +1,28 @@
+package v1
+
+import (
+	"github.com/gin-gonic/gin"
+	"gohub/pkg/auth"
+	"gohub/pkg/logger"
+	"gohub/pkg/sse"
+	"gohub/pkg/ws"
+)
+
+// RealtimeController 实时推送控制器
+type RealtimeController struct {
+	BaseAPIController
+}
+
+// WebSocket 建立 WebSocket 连接，推送当前用户的通知
+func (ctrl *RealtimeController) WebSocket(c *gin.Context) {
+	// 升级失败时 upgrader 已响应错误，只需记录日志
+	if err := ws.Serve(c, auth.CurrentUID(c)); err != nil {
+		logger.WarnString("WebSocket", "Upgrade", err.Error())
+	}
+}
+
+// Events 以 Server-Sent Events 推送当前用户的通知和关注的人的话题动态，
+// 适用于代理不支持 WebSocket 的客户端
+func (ctrl *RealtimeController) Events(c *gin.Context) {
+	sse.Serve(c, auth.CurrentUID(c))
+}
@@ -0,0 +1,69 @@
+package v1
+
+import (
+	"net/url"
+
+	"github.com/gin-gonic/gin"
+	"gohub/app/models/topic"
+	"gohub/app/models/user"
+	"gohub/app/requests"
+	"gohub/pkg/app"
+	"gohub/pkg/logger"
+	"gohub/pkg/paginator"
+	"gohub/pkg/response"
+	"gohub/pkg/search"
+)
+
+// SearchController 搜索控制器
+type SearchController struct {
+	BaseAPIController
+}
+
+// Search 按 type 搜索用户或话题，返回带高亮片段的分页结果
+func (ctrl *SearchController) Search(c *gin.Context) {
+	request := requests.SearchRequest{}
+	if ok := requests.Validate(c, &request, requests.ValidateSearch); !ok {
+		return
+	}
+
+	var hits []search.Hit
+	var searchErr error
+	baseURL := app.V1URL("search") + "?" + url.Values{"q": {request.Q}, "type": {request.Type}}.Encode()
+	pager := paginator.PaginateFunc(c, baseURL, 10, func(offset, limit int) int64 {
+		var total int64
+		hits, total, searchErr = search.NewSearch().Search(request.Type, request.Q, offset, limit)
+		return total
+	})
+	if searchErr != nil {
+		logger.LogIf(searchErr)
+		response.Abort500(c, "search.failed")
+		return
+	}
+
+	ids := make([]uint64, len(hits))
+	highlights := make(map[uint64]map[string]string, len(hits))
+	for i, hit := range hits {
+		ids[i] = hit.ID
+		highlights[hit.ID] = hit.Highlights
+	}
+
+	data := make([]gin.H, 0, len(hits))
+	switch request.Type {
+	case topic.SearchIndex.Name:
+		for _, t := range topic.GetByIDs(ids) {
+			data = append(data, gin.H{
+				"topic":      t,
+				"highlights": highlights[t.ID],
+			})
+		}
+	default:
+		for _, u := range user.GetByIDs(ids) {
+			data = append(data, gin.H{
+				"user":       u,
+				"highlights": highlights[u.ID],
+			})
+		}
+	}
+
+	response.Paginated(c, data, pager)
+}
@@ -0,0 +1,36 @@
+package v1
+
+import (
+	"github.com/gin-gonic/gin"
+	"gohub/app/models/tag"
+	"gohub/app/models/topic"
+	"gohub/app/requests"
+	"gohub/pkg/response"
+)
+
+// TagsController 标签控制器
+type TagsController struct {
+	BaseAPIController
+}
+
+// Index 标签自动补全
+func (ctrl *TagsController) Index(c *gin.Context) {
+	request := requests.TagAutocompleteRequest{}
+	if ok := requests.Validate(c, &request, requests.ValidateTagAutocomplete); !ok {
+		return
+	}
+
+	response.Data(c, tag.Autocomplete(request.Prefix))
+}
+
+// Topics 带有该标签的话题
+func (ctrl *TagsController) Topics(c *gin.Context) {
+	tagModel := tag.GetBySlug(c.Param("slug"))
+	if tagModel.ID == 0 {
+		response.Abort404(c)
+		return
+	}
+
+	data, pager := topic.PaginateByTag(c, tagModel, 10)
+	response.Paginated(c, data, pager)
+}
@@ -0,0 +1,94 @@
+package v1
+
+import (
+	"github.com/gin-gonic/gin"
+	"gohub/app/models/tag"
+	"gohub/app/models/topic"
+	"gohub/app/requests"
+	"gohub/pkg/auth"
+	"gohub/pkg/logger"
+	"gohub/pkg/response"
+)
+
+// TopicsController 话题控制器
+type TopicsController struct {
+	BaseAPIController
+}
+
+// Show 话题详情
+func (ctrl *TopicsController) Show(c *gin.Context) {
+	topicModel := topic.Get(c.Param("id"))
+	if topicModel.ID == 0 {
+		response.Abort404(c)
+		return
+	}
+	response.Data(c, topicModel)
+}
+
+// Store 发布话题，并推送到作者粉丝的 feed 和事件流
+func (ctrl *TopicsController) Store(c *gin.Context) {
+	request := requests.TopicRequest{}
+	if ok := requests.Validate(c, &request, requests.ValidateTopicSave); !ok {
+		return
+	}
+
+	topicModel := topic.Topic{
+		Title:  request.Title,
+		Body:   request.Body,
+		UserID: auth.CurrentUID(c),
+	}
+	topicModel.Create()
+	if topicModel.ID == 0 {
+		response.Abort500(c, "topic.create_failed")
+		return
+	}
+
+	tags, err := tag.SyncTopicTags(topicModel.ID, request.Tags)
+	if err != nil {
+		logger.LogIf(err)
+		response.Abort500(c, "tag.sync_failed")
+		return
+	}
+	topicModel.Tags = tags
+	topicModel.User = auth.CurrentUser(c)
+	topic.PushToFollowers(topicModel)
+	topic.PublishToFollowers("topic.created", topicModel)
+	response.Created(c, topicModel)
+}
+
+// Update 更新话题，只有作者可以更新
+func (ctrl *TopicsController) Update(c *gin.Context) {
+	topicModel := topic.Get(c.Param("id"))
+	if topicModel.ID == 0 {
+		response.Abort404(c)
+		return
+	}
+	if topicModel.UserID != auth.CurrentUID(c) {
+		response.Abort403(c)
+		return
+	}
+
+	request := requests.TopicRequest{}
+	if ok := requests.Validate(c, &request, requests.ValidateTopicSave); !ok {
+		return
+	}
+
+	topicModel.Title = request.Title
+	topicModel.Body = request.Body
+	if rowsAffected := topicModel.Save(); rowsAffected == 0 {
+		response.Abort500(c, "topic.update_failed")
+		return
+	}
+	// 未传 tags 时保留原有标签
+	if request.Tags != nil {
+		tags, err := tag.SyncTopicTags(topicModel.ID, request.Tags)
+		if err != nil {
+			logger.LogIf(err)
+			response.Abort500(c, "tag.sync_failed")
+			return
+		}
+		topicModel.Tags = tags
+	}
+	topic.PublishToFollowers("topic.updated", topicModel)
+	response.Data(c, topicModel)
+}
@@ -0,0 +1,83 @@
+package v1
+
+import (
+	"github.com/gin-gonic/gin"
+	"gohub/app/models/follow"
+	"gohub/app/models/topic"
+	"gohub/app/models/user"
+	"gohub/pkg/auth"
+	"gohub/pkg/errcode"
+	"gohub/pkg/logger"
+	"gohub/pkg/response"
+)
+
+// UsersController 用户控制器
+type UsersController struct {
+	BaseAPIController
+}
+
+// Followers 用户的粉丝列表
+func (ctrl *UsersController) Followers(c *gin.Context) {
+	userModel := user.Get(c.Param("id"))
+	if userModel.ID == 0 {
+		response.Abort404(c)
+		return
+	}
+
+	data, pager := user.PaginateFollowers(c, userModel.ID, 10)
+	response.Paginated(c, data, pager)
+}
+
+// Followings 用户关注的人列表
+func (ctrl *UsersController) Followings(c *gin.Context) {
+	userModel := user.Get(c.Param("id"))
+	if userModel.ID == 0 {
+		response.Abort404(c)
+		return
+	}
+
+	data, pager := user.PaginateFollowings(c, userModel.ID, 10)
+	response.Paginated(c, data, pager)
+}
+
+// Follow 关注用户
+func (ctrl *UsersController) Follow(c *gin.Context) {
+	userModel := user.Get(c.Param("id"))
+	if userModel.ID == 0 {
+		response.Abort404(c)
+		return
+	}
+
+	currentUID := auth.CurrentUID(c)
+	if userModel.ID == currentUID {
+		response.Abort(c, errcode.FollowSelf)
+		return
+	}
+
+	if err := follow.Create(currentUID, userModel.ID); err != nil {
+		logger.LogIf(err)
+		response.Abort500(c, "follow.failed")
+		return
+	}
+	// 关注关系变化，下次读取 feed 时重建
+	topic.ForgetFeed(currentUID)
+	response.Success(c)
+}
+
+// Unfollow 取消关注用户
+func (ctrl *UsersController) Unfollow(c *gin.Context) {
+	userModel := user.Get(c.Param("id"))
+	if userModel.ID == 0 {
+		response.Abort404(c)
+		return
+	}
+
+	currentUID := auth.CurrentUID(c)
+	if err := follow.Delete(currentUID, userModel.ID); err != nil {
+		logger.LogIf(err)
+		response.Abort500(c, "follow.failed")
+		return
+	}
+	topic.ForgetFeed(currentUID)
+	response.Success(c)
+}
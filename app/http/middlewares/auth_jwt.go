@@ -0,0 +1,38 @@
+package middlewares
+
+import (
+	"github.com/gin-gonic/gin"
+	"gohub/app/models/user"
+	"gohub/pkg/jwt"
+	"gohub/pkg/response"
+)
+
+// AuthJWT 授权中间件，解析 Token 并将当前用户存入 gin.Context
+func AuthJWT() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		// 从标头 Authorization:Bearer xxxxx 中获取信息，并验证 JWT 的准确性
+		claims, err := jwt.NewJWT().ParseToken(c)
+		if err != nil {
+			if err == jwt.ErrTokenExpired {
+				response.Unauthorized(c, "auth.token_expired")
+			} else {
+				response.Unauthorized(c, "auth.token_invalid")
+			}
+			return
+		}
+
+		// JWT 解析成功，设置用户信息
+		userModel := user.Get(claims.UserID)
+		if userModel.ID == 0 {
+			response.Unauthorized(c, "auth.user_not_found")
+			return
+		}
+
+		// 将用户信息存入 gin.context 里，后续 auth 包将从这里拿到当前用户数据
+		c.Set("current_user_id", userModel.GetStringID())
+		c.Set("current_user_name", userModel.Name)
+		c.Set("current_user", userModel)
+
+		c.Next()
+	}
+}
@@ -0,0 +1,89 @@
+package middlewares
+
+import (
+	"bytes"
+	"net/http"
+	"regexp"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gohub/pkg/config"
+	"gohub/pkg/i18n"
+	"gohub/pkg/redis"
+	"gohub/pkg/respcache"
+	"gohub/pkg/response"
+)
+
+// cacheWriter 在输出的同时记录响应内容
+type cacheWriter struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *cacheWriter) Write(b []byte) (int, error) {
+	w.body.Write(b)
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *cacheWriter) WriteString(s string) (int, error) {
+	return w.Write([]byte(s))
+}
+
+// CacheResponse 将 GET 请求的 JSON 响应缓存到 Redis，缓存按路由、查询参数、用户和语言区分，
+// tags 为响应所依赖的数据，数据变更后调用 respcache.Invalidate 使其失效。
+// 标签中的 {name} 会替换为路由参数，如 /users/:id/followers 使用 follows:{id}，只在该用户的关注关系变化时失效。
+// 列表中其他数据（如粉丝的用户名）变化时不会失效，依赖 cache.response_ttl 过期。
+// cache.response_ttl 为 0 时不缓存
+func CacheResponse(tags ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		tags := resolveCacheTags(c, tags)
+		ttl := time.Duration(config.GetInt64("cache.response_ttl")) * time.Second
+		if c.Request.Method != http.MethodGet || ttl <= 0 || redis.Redis == nil {
+			c.Next()
+			return
+		}
+
+		key := respcache.Key(c.Request.Context(), []string{
+			c.FullPath(),
+			c.Request.URL.Query().Encode(),
+			c.GetString("current_user_id"),
+			i18n.Locale(c),
+		}, tags)
+
+		if entry, ok := respcache.Get(c.Request.Context(), key); ok {
+			c.Header("X-Cache", "HIT")
+			c.Data(entry.Status, entry.ContentType, response.StampBody(c, entry.Body))
+			c.Abort()
+			return
+		}
+
+		c.Header("X-Cache", "MISS")
+		w := &cacheWriter{ResponseWriter: c.Writer}
+		c.Writer = w
+		c.Next()
+
+		contentType := w.Header().Get("Content-Type")
+		if w.Status() != http.StatusOK || !isJSONContentType(contentType) || w.body.Len() == 0 {
+			return
+		}
+		respcache.Set(c.Request.Context(), key, respcache.Entry{
+			Status:      w.Status(),
+			ContentType: contentType,
+			Body:        response.StableBody(c, w.body.Bytes()),
+		}, ttl)
+	}
+}
+
+// cacheTagParam 缓存标签中的路由参数占位符
+var cacheTagParam = regexp.MustCompile(`\{(\w+)\}`)
+
+// resolveCacheTags 将标签中的 {name} 替换为当前请求的路由参数
+func resolveCacheTags(c *gin.Context, tags []string) []string {
+	resolved := make([]string, len(tags))
+	for i, tag := range tags {
+		resolved[i] = cacheTagParam.ReplaceAllStringFunc(tag, func(placeholder string) string {
+			return c.Param(placeholder[1 : len(placeholder)-1])
+		})
+	}
+	return resolved
+}
@@ -0,0 +1,109 @@
+package middlewares
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/spf13/cast"
+	"gohub/pkg/config"
+)
+
+// CORS 处理跨域请求，预检请求在此直接返回，不会进入路由的 NoRoute 处理。
+// 允许凭证时来源不能配置为 *，否则任意网站都能携带用户凭证访问接口，启动时 panic
+func CORS() gin.HandlerFunc {
+	origins := splitConfig("cors.allowed_origins")
+	allowAll := false
+	for _, origin := range origins {
+		if origin == "*" {
+			allowAll = true
+		}
+	}
+	allowMethods := strings.Join(splitConfig("cors.allowed_methods"), ", ")
+	allowHeaders := strings.Join(splitConfig("cors.allowed_headers"), ", ")
+	exposeHeaders := strings.Join(splitConfig("cors.exposed_headers"), ", ")
+	credentials := config.GetBool("cors.allow_credentials")
+	if allowAll && credentials {
+		panic("cors: allowed_origins cannot be * when allow_credentials is enabled, list the origins explicitly")
+	}
+	maxAge := cast.ToString(config.GetInt("cors.max_age"))
+
+	return func(c *gin.Context) {
+		origin := c.GetHeader("Origin")
+		if origin == "" {
+			// 非跨域请求
+			c.Next()
+			return
+		}
+
+		// 响应会随 Origin 变化，告知缓存按 Origin 区分
+		c.Writer.Header().Add("Vary", "Origin")
+		preflight := c.Request.Method == http.MethodOptions && c.GetHeader("Access-Control-Request-Method") != ""
+
+		if !allowAll && !originAllowed(origins, origin) {
+			if preflight {
+				c.AbortWithStatus(http.StatusForbidden)
+				return
+			}
+			// 不添加 CORS 响应头，由浏览器拦截
+			c.Next()
+			return
+		}
+
+		if allowAll {
+			c.Header("Access-Control-Allow-Origin", "*")
+		} else {
+			c.Header("Access-Control-Allow-Origin", origin)
+		}
+		if credentials {
+			c.Header("Access-Control-Allow-Credentials", "true")
+		}
+
+		if preflight {
+			c.Header("Access-Control-Allow-Methods", allowMethods)
+			c.Header("Access-Control-Allow-Headers", allowHeaders)
+			c.Header("Access-Control-Max-Age", maxAge)
+			c.AbortWithStatus(http.StatusNoContent)
+			return
+		}
+
+		if exposeHeaders != "" {
+			c.Header("Access-Control-Expose-Headers", exposeHeaders)
+		}
+		c.Next()
+	}
+}
+
+// originAllowed 判断来源是否在白名单中，支持 https://*.example.com 形式的通配子域名
+func originAllowed(origins []string, origin string) bool {
+	origin = strings.ToLower(origin)
+	for _, allowed := range origins {
+		allowed = strings.ToLower(allowed)
+		if allowed == origin {
+			return true
+		}
+		if i := strings.Index(allowed, "*."); i >= 0 {
+			prefix, suffix := allowed[:i], allowed[i+1:]
+			if len(origin) <= len(prefix)+len(suffix) ||
+				!strings.HasPrefix(origin, prefix) || !strings.HasSuffix(origin, suffix) {
+				continue
+			}
+			// 通配部分只能是子域名，不能包含端口、路径等
+			if sub := origin[len(prefix) : len(origin)-len(suffix)]; !strings.ContainsAny(sub, "/:@") {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// splitConfig 读取逗号分隔的配置项
+func splitConfig(key string) []string {
+	var values []string
+	for _, value := range strings.Split(config.GetString(key), ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
@@ -0,0 +1,115 @@
+package middlewares
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"mime"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"gohub/pkg/response"
+)
+
+// etagWriter 缓存 JSON 响应以便计算 ETag，
+// 遇到非 JSON 内容或 Flush（如 SSE）时改为直接输出
+type etagWriter struct {
+	gin.ResponseWriter
+	body        bytes.Buffer
+	passthrough bool
+}
+
+func (w *etagWriter) Write(b []byte) (int, error) {
+	if !w.passthrough && !isJSONContentType(w.Header().Get("Content-Type")) {
+		w.startPassthrough()
+	}
+	if w.passthrough {
+		return w.ResponseWriter.Write(b)
+	}
+	return w.body.Write(b)
+}
+
+func (w *etagWriter) WriteString(s string) (int, error) {
+	return w.Write([]byte(s))
+}
+
+func (w *etagWriter) Flush() {
+	w.startPassthrough()
+	w.ResponseWriter.Flush()
+}
+
+// Written 内容缓存中时也视为已写入，避免后续处理重复输出
+func (w *etagWriter) Written() bool {
+	return w.body.Len() > 0 || w.ResponseWriter.Written()
+}
+
+func (w *etagWriter) startPassthrough() {
+	if w.passthrough {
+		return
+	}
+	w.passthrough = true
+	if w.body.Len() > 0 {
+		w.ResponseWriter.Write(w.body.Bytes())
+		w.body.Reset()
+	}
+}
+
+// ETag 为 GET 请求的 JSON 响应添加 ETag，请求头 If-None-Match 匹配时返回 304
+func ETag() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.Request.Method != http.MethodGet {
+			c.Next()
+			return
+		}
+
+		original := c.Writer
+		w := &etagWriter{ResponseWriter: original}
+		c.Writer = w
+		c.Next()
+		c.Writer = original
+
+		if w.passthrough {
+			return
+		}
+		body := w.body.Bytes()
+		if original.Status() == http.StatusOK && len(body) > 0 {
+			// envelope 中的 request_id 和耗时每次都不同，不参与计算
+			sum := sha256.Sum256(response.StableBody(c, body))
+			etag := `W/"` + hex.EncodeToString(sum[:16]) + `"`
+			original.Header().Set("ETag", etag)
+
+			if etagMatch(c.GetHeader("If-None-Match"), etag) {
+				original.Header().Del("Content-Type")
+				original.WriteHeader(http.StatusNotModified)
+				original.WriteHeaderNow()
+				return
+			}
+		}
+		if len(body) > 0 {
+			original.Write(body)
+		} else {
+			original.WriteHeaderNow()
+		}
+	}
+}
+
+// etagMatch If-None-Match 可以是 * 或逗号分隔的多个 ETag，使用弱比较
+func etagMatch(header, etag string) bool {
+	if header == "" {
+		return false
+	}
+	etag = strings.TrimPrefix(etag, "W/")
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == etag {
+			return true
+		}
+	}
+	return false
+}
+
+func isJSONContentType(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	return err == nil && strings.Contains(mediaType, "json")
+}
@@ -0,0 +1,19 @@
+package middlewares
+
+import (
+	"errors"
+
+	"github.com/gin-gonic/gin"
+	"gohub/pkg/response"
+)
+
+// ForceUA 强制请求必须附带 User-Agent 标头，拦截简单的脚本请求
+func ForceUA() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.Request.UserAgent() == "" {
+			response.BadRequest(c, errors.New("User-Agent 标头未找到"), "request.user_agent_missing")
+			return
+		}
+		c.Next()
+	}
+}
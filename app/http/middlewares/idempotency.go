@@ -0,0 +1,182 @@
+package middlewares
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gohub/pkg/config"
+	"gohub/pkg/errcode"
+	"gohub/pkg/jwt"
+	"gohub/pkg/redis"
+	"gohub/pkg/response"
+)
+
+const (
+	// idempotencyHeader 客户端传入的幂等键
+	idempotencyHeader = "Idempotency-Key"
+	// idempotencyReplayedHeader 响应为重放时添加该响应头
+	idempotencyReplayedHeader = "Idempotent-Replayed"
+	// maxIdempotencyKeyLen 幂等键的最大长度
+	maxIdempotencyKeyLen = 255
+)
+
+// idempotencySkipHeaders 每次请求都不同的响应头，重放时不沿用，
+// 以 Access-Control- 开头的 CORS 响应头随请求来源变化，同样不沿用
+var idempotencySkipHeaders = map[string]bool{
+	"Content-Length": true,
+	"Date":           true,
+	"Set-Cookie":     true,
+	requestIDHeader:  true,
+	"Traceparent":    true,
+	"Vary":           true,
+}
+
+// idempotencyRecord 存储在 Redis 中的请求状态及首次响应
+type idempotencyRecord struct {
+	Fingerprint string      `json:"fingerprint"`
+	Completed   bool        `json:"completed"`
+	Status      int         `json:"status,omitempty"`
+	Header      http.Header `json:"header,omitempty"`
+	Body        []byte      `json:"body,omitempty"`
+}
+
+// idempotencyWriter 记录响应内容，以便存储后重放
+type idempotencyWriter struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *idempotencyWriter) Write(b []byte) (int, error) {
+	w.body.Write(b)
+	return w.ResponseWriter.Write(b)
+}
+
+// Idempotency 处理带 Idempotency-Key 的 POST 请求：
+// 首次请求的响应存入 Redis，重复请求直接重放；首次请求还在处理中时返回 409
+func Idempotency() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := c.GetHeader(idempotencyHeader)
+		if c.Request.Method != http.MethodPost || key == "" || redis.Redis == nil {
+			c.Next()
+			return
+		}
+		if len(key) > maxIdempotencyKeyLen {
+			response.Abort(c, errcode.IdempotencyKeyInvalid)
+			return
+		}
+
+		// 使用请求方法、路径和 body 作为请求指纹，相同的键用于不同请求时拒绝，
+		// body 超过 idempotency.max_body_size 时不再读取，避免大请求占满内存
+		maxBodySize := config.GetInt64("idempotency.max_body_size")
+		body, err := io.ReadAll(io.LimitReader(c.Request.Body, maxBodySize+1))
+		if err != nil {
+			response.BadRequest(c, err)
+			return
+		}
+		if int64(len(body)) > maxBodySize {
+			response.Abort(c, errcode.IdempotencyBodyTooLarge)
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+		fingerprint := hashString(c.Request.Method, c.Request.URL.Path, string(body))
+
+		rds := redis.Redis.WithContext(c.Request.Context())
+		// 幂等键由客户端生成，不同调用方可能重复，键中加入调用方身份，避免重放其他用户的响应
+		redisKey := config.GetString("app.name") + ":idempotency:" + hashString(idempotencyCaller(c), c.Request.URL.Path, key)
+
+		pending, _ := json.Marshal(idempotencyRecord{Fingerprint: fingerprint})
+		lockTTL := time.Duration(config.GetInt64("idempotency.lock_ttl")) * time.Second
+		if !rds.SetNX(redisKey, pending, lockTTL) {
+			var record idempotencyRecord
+			if err := json.Unmarshal([]byte(rds.Get(redisKey)), &record); err != nil {
+				// 记录刚好过期或 Redis 不可用，按普通请求处理
+				c.Next()
+				return
+			}
+			replayIdempotent(c, record, fingerprint)
+			return
+		}
+
+		// 未能保存响应时（服务端错误或处理中 panic）释放锁，允许客户端使用相同的键重试。
+		// 请求的 context 可能已随客户端断开而取消，释放锁不使用该 context
+		completed := false
+		defer func() {
+			if !completed {
+				redis.Redis.Del(redisKey)
+			}
+		}()
+
+		w := &idempotencyWriter{ResponseWriter: c.Writer}
+		c.Writer = w
+		c.Next()
+
+		status := w.Status()
+		if status >= http.StatusInternalServerError {
+			return
+		}
+
+		header := make(http.Header)
+		for name, values := range w.Header() {
+			if !idempotencySkipHeaders[name] && !strings.HasPrefix(name, "Access-Control-") {
+				header[name] = values
+			}
+		}
+		record, _ := json.Marshal(idempotencyRecord{
+			Fingerprint: fingerprint,
+			Completed:   true,
+			Status:      status,
+			Header:      header,
+			Body:        w.body.Bytes(),
+		})
+		completed = rds.Set(redisKey, record, time.Duration(config.GetInt64("idempotency.ttl"))*time.Second)
+	}
+}
+
+// replayIdempotent 处理重复的请求
+func replayIdempotent(c *gin.Context, record idempotencyRecord, fingerprint string) {
+	if record.Fingerprint != fingerprint {
+		response.Abort(c, errcode.IdempotencyKeyReused)
+		return
+	}
+	if !record.Completed {
+		response.Abort(c, errcode.IdempotencyInProgress)
+		return
+	}
+
+	for name, values := range record.Header {
+		c.Writer.Header()[name] = values
+	}
+	c.Header(idempotencyReplayedHeader, "true")
+	c.Writer.WriteHeader(record.Status)
+	c.Writer.Write(record.Body)
+	c.Abort()
+}
+
+// idempotencyCaller 调用方身份：携带有效 Token 时为用户 ID，否则为客户端 IP。
+// 本中间件在路由的 AuthJWT 之前执行，因此自行解析 Token，Token 无效时交由后续的 AuthJWT 处理
+func idempotencyCaller(c *gin.Context) string {
+	if uid := c.GetString("current_user_id"); uid != "" {
+		return "user:" + uid
+	}
+	if claims, err := jwt.NewJWT().ParseToken(c); err == nil {
+		return "user:" + claims.UserID
+	}
+	return "ip:" + c.ClientIP()
+}
+
+// hashString 计算多个字符串的 sha256，用于生成固定长度的键
+func hashString(parts ...string) string {
+	h := sha256.New()
+	for _, part := range parts {
+		h.Write([]byte(part))
+		h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
@@ -0,0 +1,168 @@
+package middlewares
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"mime"
+	"net/http"
+	"net/url"
+	"strings"
+	"unicode/utf8"
+
+	"gohub/pkg/config"
+)
+
+// redactedValue 脱敏后替换的内容
+const redactedValue = "******"
+
+// maxBufferedBody 超过该大小的 body 不再缓存和解析，日志中只记录大小
+const maxBufferedBody = 1 << 20
+
+// logRedactor 请求日志脱敏规则，在 Logger 初始化时从配置读取
+type logRedactor struct {
+	keys        map[string]bool
+	headers     map[string]bool
+	maxBodySize int
+}
+
+func newLogRedactor() *logRedactor {
+	r := &logRedactor{
+		keys:        make(map[string]bool),
+		headers:     make(map[string]bool),
+		maxBodySize: config.GetInt("log.max_body_size"),
+	}
+	for _, key := range splitConfig("log.redact_keys") {
+		r.keys[strings.ToLower(key)] = true
+	}
+	for _, header := range splitConfig("log.redact_headers") {
+		r.headers[http.CanonicalHeaderKey(header)] = true
+	}
+	return r
+}
+
+// Headers 返回用于记录的请求头，敏感请求头的值被替换
+func (r *logRedactor) Headers(header http.Header) map[string]string {
+	result := make(map[string]string, len(header))
+	for name, values := range header {
+		if r.headers[http.CanonicalHeaderKey(name)] {
+			result[name] = redactedValue
+		} else {
+			result[name] = strings.Join(values, ", ")
+		}
+	}
+	return result
+}
+
+// Body 返回用于记录的 body：非文本内容只记录类型和大小，
+// JSON 和表单中的敏感字段被替换，超过 log.max_body_size 的部分被截断
+func (r *logRedactor) Body(contentType string, body []byte, size int) string {
+	if size == 0 {
+		return ""
+	}
+	if !loggableContentType(contentType, body) || size > len(body) {
+		// 未知长度（chunked）的上传不读取，也就不知道大小
+		if size < 0 {
+			return fmt.Sprintf("[%s body omitted]", contentType)
+		}
+		return fmt.Sprintf("[%s body omitted, %d bytes]", contentType, size)
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	switch {
+	case strings.Contains(mediaType, "json"):
+		body = r.redactJSON(body)
+	case mediaType == "application/x-www-form-urlencoded":
+		body = r.redactForm(body)
+	}
+
+	if r.maxBodySize > 0 && len(body) > r.maxBodySize {
+		return fmt.Sprintf("%s...[truncated, %d bytes]", body[:r.maxBodySize], size)
+	}
+	return string(body)
+}
+
+// redactJSON 递归替换 JSON 中的敏感字段，无法解析时整体隐藏，避免泄露
+func (r *logRedactor) redactJSON(body []byte) []byte {
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	var data interface{}
+	if err := decoder.Decode(&data); err != nil {
+		return []byte(fmt.Sprintf("[invalid json omitted, %d bytes]", len(body)))
+	}
+	redacted, err := json.Marshal(r.redactValue(data))
+	if err != nil {
+		return []byte(fmt.Sprintf("[invalid json omitted, %d bytes]", len(body)))
+	}
+	return redacted
+}
+
+func (r *logRedactor) redactValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, item := range v {
+			if r.keys[strings.ToLower(key)] {
+				v[key] = redactedValue
+			} else {
+				v[key] = r.redactValue(item)
+			}
+		}
+	case []interface{}:
+		for i, item := range v {
+			v[i] = r.redactValue(item)
+		}
+	}
+	return value
+}
+
+// URI 返回用于记录的请求 URI，query 中的敏感参数被替换，如 WebSocket 和 SSE 使用的 token
+func (r *logRedactor) URI(u *url.URL) string {
+	if len(u.RawQuery) == 0 {
+		return u.Path
+	}
+	return u.Path + "?" + r.Query(u.RawQuery)
+}
+
+// Query 返回用于记录的 query，敏感参数被替换
+func (r *logRedactor) Query(rawQuery string) string {
+	if len(rawQuery) == 0 {
+		return rawQuery
+	}
+	return string(r.redactForm([]byte(rawQuery)))
+}
+
+// redactForm 替换 urlencoded 表单中的敏感字段
+func (r *logRedactor) redactForm(body []byte) []byte {
+	values, err := url.ParseQuery(string(body))
+	if err != nil {
+		return []byte(fmt.Sprintf("[invalid form omitted, %d bytes]", len(body)))
+	}
+	for key := range values {
+		if r.keys[strings.ToLower(key)] {
+			values[key] = []string{redactedValue}
+		}
+	}
+	return []byte(values.Encode())
+}
+
+// loggableContentType 只记录文本类的 body，multipart、图片、SSE 等流式或二进制内容不记录
+func loggableContentType(contentType string, body []byte) bool {
+	if contentType == "" {
+		return utf8.Valid(body)
+	}
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	switch {
+	case mediaType == "text/event-stream":
+		return false
+	case strings.HasPrefix(mediaType, "text/"),
+		strings.Contains(mediaType, "json"),
+		strings.Contains(mediaType, "xml"),
+		mediaType == "application/x-www-form-urlencoded":
+		return true
+	default:
+		return false
+	}
+}
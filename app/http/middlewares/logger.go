@@ -10,35 +10,64 @@ import (
 	"go.uber.org/zap"
 	"gohub/pkg/helpers"
 	"gohub/pkg/logger"
+	"gohub/pkg/response"
 )
 
 type responseBodyWriter struct {
 	gin.ResponseWriter
 	body *bytes.Buffer
+	// size 响应的实际大小，超过 maxBufferedBody 后不再缓存
+	size int
 }
 
-func (r responseBodyWriter) Write(b []byte) (int, error) {
-	r.body.Write(b)
+func (r *responseBodyWriter) Write(b []byte) (int, error) {
+	r.size += len(b)
+	if r.size <= maxBufferedBody {
+		r.body.Write(b)
+	}
 	return r.ResponseWriter.Write(b)
 }
 
+// readCloser 组合读取过的内容和原 body，Close 时关闭原 body
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
+
 // Logger 记录请求日志
 func Logger() gin.HandlerFunc {
+	skipPaths := make(map[string]bool)
+	for _, path := range splitConfig("log.access_skip_paths") {
+		skipPaths[path] = true
+	}
+	redactor := newLogRedactor()
+
 	return func(c *gin.Context) {
+		if skipPaths[c.Request.URL.Path] {
+			c.Next()
+			return
+		}
+
 		// 获取respone内容
 		w := &responseBodyWriter{body: &bytes.Buffer{}, ResponseWriter: c.Writer}
 		c.Writer = w
 
-		// 获取请求数据
+		// 获取请求数据，只读取可记录的文本内容，上传文件等不读取
 		var requestBody []byte
-		if c.Request.Body != nil {
-			// c.Request.Body是个buffer对象，只能读取一次
-			requestBody, _ = io.ReadAll(c.Request.Body)
-			// 读取后，重新赋值c.Request.Body，供后续其他操作
-			c.Request.Body = io.NopCloser(bytes.NewBuffer(requestBody))
+		requestSize := int(c.Request.ContentLength)
+		requestType := c.ContentType()
+		if c.Request.Body != nil && requestSize != 0 && loggableContentType(requestType, nil) {
+			// c.Request.Body是个buffer对象，只能读取一次，最多读取 maxBufferedBody+1 字节用于判断是否超长
+			requestBody, _ = io.ReadAll(io.LimitReader(c.Request.Body, maxBufferedBody+1))
+			// 读取后，重新赋值c.Request.Body，未读取的部分继续从原 body 读取
+			c.Request.Body = readCloser{io.MultiReader(bytes.NewReader(requestBody), c.Request.Body), c.Request.Body}
+			if requestSize < 0 {
+				requestSize = len(requestBody)
+			}
 		}
-		// 设置开始时间
+		// 设置开始时间，response 统一格式中的耗时也以此为准
 		start := time.Now()
+		c.Set(response.StartTimeKey, start)
 		c.Next()
 
 		// 记录日志的逻辑
@@ -47,25 +76,27 @@ func Logger() gin.HandlerFunc {
 
 		logFields := []zap.Field{
 			zap.Int("status", responStatus),
-			zap.String("request", c.Request.Method+""+c.Request.URL.String()),
-			zap.String("query", c.Request.URL.RawQuery),
+			zap.String("request", c.Request.Method+""+redactor.URI(c.Request.URL)),
+			zap.String("query", redactor.Query(c.Request.URL.RawQuery)),
 			zap.String("ip", c.ClientIP()),
 			zap.String("user-agent", c.Request.UserAgent()),
 			zap.String("errors", c.Errors.ByType(gin.ErrorTypePrivate).String()),
 			zap.String("time", helpers.MicrosecondStr(cost)),
 		}
 		if c.Request.Method == "POST" || c.Request.Method == "PUT" || c.Request.Method == "DELETE" {
+			// 请求头，Authorization、Cookie 等敏感内容已脱敏
+			logFields = append(logFields, zap.Any("RequestHeaders", redactor.Headers(c.Request.Header)))
 			// 请求内容
-			logFields = append(logFields, zap.String("RequestBody", string(requestBody)))
+			logFields = append(logFields, zap.String("RequestBody", redactor.Body(requestType, requestBody, requestSize)))
 			// 响应内容
-			logFields = append(logFields, zap.String("RequestBody", w.body.String()))
+			logFields = append(logFields, zap.String("ResponseBody", redactor.Body(w.Header().Get("Content-Type"), w.body.Bytes(), w.size)))
 		}
 		if responStatus >= 400 && responStatus <= 499 {
-			logger.Warn("HTTP Warning"+cast.ToString(responStatus), logFields...)
+			logger.WarnContext(c.Request.Context(), "HTTP Warning"+cast.ToString(responStatus), logFields...)
 		} else if responStatus >= 500 && responStatus <= 599 {
-			logger.Error("HTTP Error"+cast.ToString(responStatus), logFields...)
+			logger.ErrorContext(c.Request.Context(), "HTTP Error"+cast.ToString(responStatus), logFields...)
 		} else {
-			logger.Debug("HTTP Access Log", logFields...)
+			logger.DebugContext(c.Request.Context(), "HTTP Access Log", logFields...)
 		}
 	}
 }
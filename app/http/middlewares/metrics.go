@@ -0,0 +1,41 @@
+package middlewares
+
+import (
+	"crypto/subtle"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gohub/pkg/config"
+	"gohub/pkg/metrics"
+	"gohub/pkg/response"
+)
+
+// Metrics 按路由模板和状态码记录请求数与耗时，需注册在 Recovery 之前，才能统计 panic 产生的 500
+func Metrics() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+
+		// 使用路由模板而不是实际路径，避免 /users/1、/users/2 产生无数标签
+		route := c.FullPath()
+		if route == "" {
+			route = "unmatched"
+		}
+		metrics.ObserveHTTP(c.Request.Method, route, c.Writer.Status(), time.Since(start))
+	}
+}
+
+// MetricsAuth 校验抓取指标的令牌，未配置 metrics.token 时放行
+func MetricsAuth() gin.HandlerFunc {
+	token := config.GetString("metrics.token")
+	return func(c *gin.Context) {
+		if len(token) > 0 {
+			expected := []byte("Bearer " + token)
+			if subtle.ConstantTimeCompare([]byte(c.GetHeader("Authorization")), expected) != 1 {
+				response.Unauthorized(c)
+				return
+			}
+		}
+		c.Next()
+	}
+}
@@ -32,7 +32,7 @@ func Recovery() gin.HandlerFunc {
 				}
 				// 连接中断情况
 				if brokenPipe {
-					logger.Error(c.Request.URL.Path,
+					logger.ErrorContext(c.Request.Context(), c.Request.URL.Path,
 						zap.Time("time", time.Now()),
 						zap.Any("error", err),
 						zap.String("request", string(httpRequest)),
@@ -43,7 +43,7 @@ func Recovery() gin.HandlerFunc {
 					return
 				}
 				// 不是连接中断，记录堆栈信息
-				logger.Error("recovery from paninc",
+				logger.ErrorContext(c.Request.Context(), "recovery from paninc",
 					zap.Time("time", time.Now()),
 					zap.Any("error", err),
 					zap.String("request", string(httpRequest)),
@@ -0,0 +1,35 @@
+package middlewares
+
+import (
+	"regexp"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gohub/pkg/logger"
+	"gohub/pkg/response"
+)
+
+// requestIDHeader 请求 ID 的请求头和响应头
+const requestIDHeader = "X-Request-ID"
+
+// validRequestID 允许沿用的请求 ID，防止日志注入和超长内容
+var validRequestID = regexp.MustCompile(`^[A-Za-z0-9._:-]{1,128}$`)
+
+// RequestID 为每个请求分配 ID，优先沿用上游传入的 X-Request-ID
+// 请求 ID 存入 gin.Context 和 c.Request.Context()，访问日志、Recovery 及 logger.*Context 的日志会附带。
+// GORM 和 Redis 只有通过 DB.WithContext、Redis.WithContext 传入请求的 context 时才会附带，
+// 目前为分页查询、接口缓存、幂等和 SSE，其他模型方法使用全局的 DB 和 Redis，其日志不含请求 ID
+func RequestID() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		requestID := c.GetHeader(requestIDHeader)
+		if !validRequestID.MatchString(requestID) {
+			requestID = uuid.New().String()
+		}
+
+		c.Set(response.RequestIDKey, requestID)
+		c.Request = c.Request.WithContext(logger.WithRequestID(c.Request.Context(), requestID))
+		c.Header(requestIDHeader, requestID)
+
+		c.Next()
+	}
+}
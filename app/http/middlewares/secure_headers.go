@@ -0,0 +1,52 @@
+package middlewares
+
+import (
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/spf13/cast"
+	"gohub/pkg/app"
+	"gohub/pkg/config"
+)
+
+// SecureHeaders 添加安全相关的响应头
+func SecureHeaders() gin.HandlerFunc {
+	frameOptions := config.GetString("security.frame_options")
+	referrerPolicy := config.GetString("security.referrer_policy")
+	csp := config.GetString("security.content_security_policy")
+
+	// 本地开发通常没有 HTTPS，发送 HSTS 会导致浏览器之后强制使用 HTTPS 访问 localhost
+	hsts := ""
+	if maxAge := config.GetInt("security.hsts_max_age"); maxAge > 0 && !app.IsLocal() {
+		hsts = "max-age=" + cast.ToString(maxAge)
+		if config.GetBool("security.hsts_include_subdomains") {
+			hsts += "; includeSubDomains"
+		}
+	}
+
+	return func(c *gin.Context) {
+		header := c.Writer.Header()
+		header.Set("X-Content-Type-Options", "nosniff")
+		if frameOptions != "" {
+			header.Set("X-Frame-Options", frameOptions)
+		}
+		if referrerPolicy != "" {
+			header.Set("Referrer-Policy", referrerPolicy)
+		}
+		if csp != "" {
+			header.Set("Content-Security-Policy", csp)
+		}
+		if hsts != "" && isHTTPS(c) {
+			header.Set("Strict-Transport-Security", hsts)
+		}
+		c.Next()
+	}
+}
+
+// isHTTPS 直接使用 TLS，或在反向代理后由代理标明原始协议为 https
+func isHTTPS(c *gin.Context) bool {
+	if c.Request.TLS != nil {
+		return true
+	}
+	return strings.EqualFold(c.GetHeader("X-Forwarded-Proto"), "https")
+}
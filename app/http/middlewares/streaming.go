@@ -0,0 +1,59 @@
+package middlewares
+
+import (
+	"context"
+	"net"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gohub/pkg/jwt"
+	"gohub/pkg/logger"
+)
+
+// connContextKey 请求的底层连接在 context 中的键
+type connContextKey struct{}
+
+// streamsCtx 所有长连接请求共用，服务关闭时取消，通知 SSE 等长连接结束
+var streamsCtx, cancelStreams = context.WithCancel(context.Background())
+
+// ConnContext 用作 http.Server 的 ConnContext，将底层连接存入请求的 context，
+// Streaming 据此取消单个请求的写超时
+func ConnContext(ctx context.Context, c net.Conn) context.Context {
+	return context.WithValue(ctx, connContextKey{}, c)
+}
+
+// CloseStreams 结束所有长连接请求，用于 http.Server 的 RegisterOnShutdown，
+// Shutdown 不会等待或中断长连接，需主动通知
+func CloseStreams() {
+	cancelStreams()
+}
+
+// Streaming 长连接路由（SSE、WebSocket）使用：
+// 1. 取消 http.Server 为本次请求设置的 WriteTimeout，其他路由仍受其保护；
+// 2. 服务关闭时取消请求的 context，让推送循环退出；
+// 3. 浏览器无法为长连接设置请求头，允许使用 URL 参数 token 认证，需放在 AuthJWT 之前
+func Streaming() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		jwt.AllowQueryToken(c)
+
+		if conn, ok := c.Request.Context().Value(connContextKey{}).(net.Conn); ok {
+			// 零值表示不设置超时
+			if err := conn.SetWriteDeadline(time.Time{}); err != nil {
+				logger.WarnString("Streaming", "SetWriteDeadline", err.Error())
+			}
+		}
+
+		ctx, cancel := context.WithCancel(c.Request.Context())
+		defer cancel()
+		go func() {
+			select {
+			case <-streamsCtx.Done():
+				cancel()
+			case <-ctx.Done():
+			}
+		}()
+
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+	}
+}
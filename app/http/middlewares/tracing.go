@@ -0,0 +1,55 @@
+package middlewares
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.12.0"
+	"go.opentelemetry.io/otel/trace"
+	"gohub/pkg/tracing"
+)
+
+// Tracing 为每个请求创建 span，请求头带有 W3C traceparent 时作为其子 span
+func Tracing() gin.HandlerFunc {
+	tracer := tracing.Tracer()
+	propagator := otel.GetTextMapPropagator()
+	// 与请求日志使用相同的脱敏规则，避免 query 中的 token 写入 span
+	redactor := newLogRedactor()
+
+	return func(c *gin.Context) {
+		ctx := propagator.Extract(c.Request.Context(), propagation.HeaderCarrier(c.Request.Header))
+
+		route := c.FullPath()
+		if route == "" {
+			route = "unmatched"
+		}
+		ctx, span := tracer.Start(ctx, c.Request.Method+" "+route,
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(
+				semconv.HTTPMethodKey.String(c.Request.Method),
+				semconv.HTTPRouteKey.String(route),
+				semconv.HTTPTargetKey.String(redactor.URI(c.Request.URL)),
+				semconv.HTTPClientIPKey.String(c.ClientIP()),
+				semconv.HTTPUserAgentKey.String(c.Request.UserAgent()),
+			),
+		)
+		defer span.End()
+
+		// 响应头返回 traceparent，方便客户端反馈问题时提供
+		propagator.Inject(ctx, propagation.HeaderCarrier(c.Writer.Header()))
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+
+		status := c.Writer.Status()
+		span.SetAttributes(semconv.HTTPStatusCodeKey.Int(status))
+		if errs := c.Errors.ByType(gin.ErrorTypePrivate); len(errs) > 0 {
+			span.RecordError(errs.Last())
+		}
+		if status >= http.StatusInternalServerError {
+			span.SetStatus(codes.Error, http.StatusText(status))
+		}
+	}
+}
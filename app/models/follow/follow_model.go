@@ -0,0 +1,14 @@
+// Package follow 存放用户关注关系 Model 相关逻辑
+package follow
+
+import "gohub/app/models"
+
+// Follow 关注关系，FollowerID 关注了 UserID
+type Follow struct {
+	models.BaseModel
+
+	UserID     uint64 `gorm:"uniqueIndex:idx_user_follower;not null" json:"user_id"`
+	FollowerID uint64 `gorm:"uniqueIndex:idx_user_follower;index;not null" json:"follower_id"`
+
+	models.CommonTimestampsField
+}
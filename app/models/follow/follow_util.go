@@ -0,0 +1,65 @@
+package follow
+
+import (
+	"github.com/spf13/cast"
+	"gohub/pkg/database"
+	"gohub/pkg/notify"
+	"gohub/pkg/respcache"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// CacheTag 依赖 userID 关注关系的接口缓存标签，如粉丝列表、关注列表
+// userID 可以是路由参数占位符，如 CacheTag("{id}")，见 middlewares.CacheResponse
+func CacheTag(userID interface{}) string {
+	return "follows:" + cast.ToString(userID)
+}
+
+// Create 关注用户，重复关注不报错，首次关注时通知被关注的用户
+func Create(followerID, userID uint64) error {
+	result := database.DB.Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&Follow{UserID: userID, FollowerID: followerID})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected > 0 {
+		// 在事务提交后清除双方的粉丝列表、关注列表缓存
+		respcache.Invalidate(CacheTag(userID), CacheTag(followerID))
+		notify.Send(userID, notify.TypeUserFollowed, map[string]interface{}{
+			"follower_id": followerID,
+		})
+	}
+	return nil
+}
+
+// Delete 取消关注
+func Delete(followerID, userID uint64) error {
+	result := database.DB.Where("user_id = ? AND follower_id = ?", userID, followerID).
+		Delete(&Follow{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected > 0 {
+		respcache.Invalidate(CacheTag(userID), CacheTag(followerID))
+	}
+	return nil
+}
+
+// IsFollowing 判断 followerID 是否已关注 userID
+func IsFollowing(followerID, userID uint64) bool {
+	var count int64
+	database.DB.Model(Follow{}).
+		Where("user_id = ? AND follower_id = ?", userID, followerID).
+		Count(&count)
+	return count > 0
+}
+
+// FollowerIDs 子查询：关注了 userID 的用户 ID
+func FollowerIDs(userID uint64) *gorm.DB {
+	return database.DB.Model(Follow{}).Select("follower_id").Where("user_id = ?", userID)
+}
+
+// FollowingIDs 子查询：userID 关注的用户 ID
+func FollowingIDs(userID uint64) *gorm.DB {
+	return database.DB.Model(Follow{}).Select("user_id").Where("follower_id = ?", userID)
+}
@@ -1,16 +1,25 @@
 package models
 
-import "time"
+import (
+	"time"
+
+	"github.com/spf13/cast"
+)
 
 // package modles 模型通用属性和方法
 
 // BaseModel 模型基类
 type BaseModel struct {
-	ID uint64 `gorm:"column:id;primaryKey;autoIncrement;"json:"id,omitempty"`
+	ID uint64 `gorm:"column:id;primaryKey;autoIncrement;" json:"id,omitempty"`
 }
 
 // CommonTimestampsField时间戳
 type CommonTimestampsField struct {
-	CreatedAt time.Time `gorm:"column:created_at;index;"json:"created_at,omitempty"`
-	UpdatedAt time.Time `gorm:"column:updated_at;index;"json:"Updated_at,o,omitempty"`
+	CreatedAt time.Time `gorm:"column:created_at;index;" json:"created_at,omitempty"`
+	UpdatedAt time.Time `gorm:"column:updated_at;index;" json:"updated_at,omitempty"`
+}
+
+// GetStringID 获取 ID 的字符串格式
+func (a BaseModel) GetStringID() string {
+	return cast.ToString(a.ID)
 }
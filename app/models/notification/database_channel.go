@@ -0,0 +1,22 @@
+package notification
+
+import (
+	"gohub/pkg/database"
+	"gohub/pkg/notify"
+)
+
+// DatabaseChannel 实现 notify.Channel interface，将通知存入数据库
+type DatabaseChannel struct{}
+
+// Send 实现 notify.Channel interface 的 Send 方法
+func (ch *DatabaseChannel) Send(n notify.Notification) error {
+	err := database.DB.Create(&Notification{
+		UserID: n.UserID,
+		Type:   n.Type,
+		Data:   n.Data,
+	}).Error
+	if err == nil {
+		forgetUnreadCount(n.UserID)
+	}
+	return err
+}
@@ -0,0 +1,51 @@
+// Package notification 存放站内通知 Model 相关逻辑
+package notification
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"gohub/app/models"
+)
+
+// Notification 站内通知
+type Notification struct {
+	models.BaseModel
+
+	UserID uint64     `gorm:"index;not null" json:"user_id"`
+	Type   string     `gorm:"type:varchar(64);not null" json:"type"`
+	Data   Data       `gorm:"type:text" json:"data"`
+	ReadAt *time.Time `gorm:"index" json:"read_at"`
+
+	models.CommonTimestampsField
+}
+
+// Data 通知内容，以 JSON 格式存储
+type Data map[string]interface{}
+
+// Value 实现 driver.Valuer interface
+func (d Data) Value() (driver.Value, error) {
+	if d == nil {
+		return "{}", nil
+	}
+	b, err := json.Marshal(d)
+	return string(b), err
+}
+
+// Scan 实现 sql.Scanner interface
+func (d *Data) Scan(value interface{}) error {
+	var b []byte
+	switch v := value.(type) {
+	case []byte:
+		b = v
+	case string:
+		b = []byte(v)
+	case nil:
+		return nil
+	default:
+		return errors.New("notification data 类型错误")
+	}
+	return json.Unmarshal(b, d)
+}
@@ -0,0 +1,73 @@
+package notification
+
+import (
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/spf13/cast"
+	"gohub/pkg/app"
+	"gohub/pkg/config"
+	"gohub/pkg/database"
+	"gohub/pkg/paginator"
+	"gohub/pkg/redis"
+)
+
+// Paginate 分页获取用户的通知，最新的在前
+func Paginate(c *gin.Context, userID uint64, perPage int) (notifications []Notification, paging paginator.Paging) {
+	paging = paginator.Paginate(
+		c,
+		database.DB.Model(Notification{}).Where("user_id = ?", userID).Order("created_at desc"),
+		&notifications,
+		app.V1URL(database.TableName(&Notification{})),
+		perPage,
+	)
+	return
+}
+
+// Get 获取用户的某条通知
+func Get(userID uint64, idstr string) (notificationModel Notification) {
+	database.DB.Where("id = ? AND user_id = ?", idstr, userID).First(&notificationModel)
+	return
+}
+
+// MarkRead 将用户的某条通知标记为已读
+func MarkRead(userID uint64, idstr string) int64 {
+	result := database.DB.Model(Notification{}).
+		Where("id = ? AND user_id = ? AND read_at IS NULL", idstr, userID).
+		Update("read_at", time.Now())
+	forgetUnreadCount(userID)
+	return result.RowsAffected
+}
+
+// MarkAllRead 将用户的所有通知标记为已读
+func MarkAllRead(userID uint64) int64 {
+	result := database.DB.Model(Notification{}).
+		Where("user_id = ? AND read_at IS NULL", userID).
+		Update("read_at", time.Now())
+	forgetUnreadCount(userID)
+	return result.RowsAffected
+}
+
+// UnreadCount 用户未读通知数，优先从 Redis 缓存读取
+func UnreadCount(userID uint64) int64 {
+	key := unreadCountKey(userID)
+	if cached := redis.Redis.Get(key); cached != "" {
+		return cast.ToInt64(cached)
+	}
+
+	var count int64
+	database.DB.Model(Notification{}).
+		Where("user_id = ? AND read_at IS NULL", userID).
+		Count(&count)
+	redis.Redis.Set(key, count, time.Minute*time.Duration(config.GetInt64("notification.unread_count_expire_time")))
+	return count
+}
+
+// forgetUnreadCount 清除未读数缓存，下次读取时重新统计
+func forgetUnreadCount(userID uint64) {
+	redis.Redis.Del(unreadCountKey(userID))
+}
+
+func unreadCountKey(userID uint64) string {
+	return config.GetString("app.name") + ":notifications:unread:" + cast.ToString(userID)
+}
@@ -0,0 +1,21 @@
+// Package tag 存放话题标签 Model 相关逻辑
+package tag
+
+import "gohub/app/models"
+
+// Tag 话题标签
+type Tag struct {
+	models.BaseModel
+
+	Name        string `gorm:"type:varchar(64);not null" json:"name"`
+	Slug        string `gorm:"type:varchar(64);uniqueIndex;not null" json:"slug"`
+	TopicsCount int64  `gorm:"default:0;not null" json:"topics_count"`
+
+	models.CommonTimestampsField
+}
+
+// TopicTag 话题和标签的关联
+type TopicTag struct {
+	TopicID uint64 `gorm:"primaryKey" json:"topic_id"`
+	TagID   uint64 `gorm:"primaryKey;index" json:"tag_id"`
+}
@@ -0,0 +1,135 @@
+package tag
+
+import (
+	"strings"
+	"unicode"
+
+	"gohub/pkg/config"
+	"gohub/pkg/database"
+	"gohub/pkg/redis"
+	"gohub/pkg/respcache"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// CacheTag 依赖标签数据的接口缓存标签，如标签自动补全
+const CacheTag = "tags"
+
+// Slugify 由标签名生成 slug：转小写，空白和符号替换为 -，保留中文
+func Slugify(name string) string {
+	var b strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(strings.TrimSpace(name)) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(r)
+			dash = false
+		} else if !dash && b.Len() > 0 {
+			b.WriteRune('-')
+			dash = true
+		}
+	}
+	return strings.TrimSuffix(b.String(), "-")
+}
+
+// GetBySlug 通过 slug 获取标签
+func GetBySlug(slug string) (tagModel Tag) {
+	database.DB.Where("slug = ?", slug).First(&tagModel)
+	return
+}
+
+// TopicIDs 子查询：带有该标签的话题 ID
+func TopicIDs(tagID uint64) *gorm.DB {
+	return database.DB.Model(TopicTag{}).Select("topic_id").Where("tag_id = ?", tagID)
+}
+
+// Autocomplete 返回 slug 以 prefix 开头的标签，使用次数多的在前
+// 先从 Redis 按字典序取出较多的候选，再由数据库按使用次数排序取前几个
+func Autocomplete(prefix string) (tags []Tag) {
+	slugs := redis.Redis.ZRangeByLex(autocompleteKey(), Slugify(prefix), config.GetInt64("tag.autocomplete_candidates"))
+	if len(slugs) == 0 {
+		return []Tag{}
+	}
+	database.DB.Where("slug IN ?", slugs).
+		Order("topics_count desc, slug").
+		Limit(config.GetInt("tag.autocomplete_limit")).
+		Find(&tags)
+	return
+}
+
+// SyncTopicTags 更新话题的标签，创建和更新话题时调用，返回话题现在的标签
+// 会新建不存在的标签，维护 topics_count，删除不再被任何话题使用的标签，并更新自动补全索引
+func SyncTopicTags(topicID uint64, names []string) ([]Tag, error) {
+	var slugs, deletedSlugs []string
+	tags := []Tag{}
+	err := database.DB.Transaction(func(tx *gorm.DB) error {
+		var oldTagIDs []uint64
+		if err := tx.Model(&TopicTag{}).Where("topic_id = ?", topicID).Pluck("tag_id", &oldTagIDs).Error; err != nil {
+			return err
+		}
+		if len(oldTagIDs) > 0 {
+			if err := tx.Where("topic_id = ?", topicID).Delete(&TopicTag{}).Error; err != nil {
+				return err
+			}
+			if err := tx.Model(&Tag{}).Where("id IN ?", oldTagIDs).
+				UpdateColumn("topics_count", gorm.Expr("topics_count - 1")).Error; err != nil {
+				return err
+			}
+		}
+
+		seen := make(map[string]bool, len(names))
+		for _, name := range names {
+			slug := Slugify(name)
+			if len(slug) == 0 || seen[slug] {
+				continue
+			}
+			seen[slug] = true
+			tagModel := Tag{Name: strings.TrimSpace(name), Slug: slug}
+			if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&tagModel).Error; err != nil {
+				return err
+			}
+			if err := tx.Where("slug = ?", slug).First(&tagModel).Error; err != nil {
+				return err
+			}
+			if err := tx.Clauses(clause.OnConflict{DoNothing: true}).
+				Create(&TopicTag{TopicID: topicID, TagID: tagModel.ID}).Error; err != nil {
+				return err
+			}
+			if err := tx.Model(&tagModel).
+				UpdateColumn("topics_count", gorm.Expr("topics_count + 1")).Error; err != nil {
+				return err
+			}
+			tagModel.TopicsCount++
+			tags = append(tags, tagModel)
+			slugs = append(slugs, slug)
+		}
+
+		if len(oldTagIDs) == 0 {
+			return nil
+		}
+		unused := tx.Model(&Tag{}).Where("id IN ? AND topics_count <= 0", oldTagIDs)
+		if err := unused.Pluck("slug", &deletedSlugs).Error; err != nil {
+			return err
+		}
+		if len(deletedSlugs) == 0 {
+			return nil
+		}
+		return tx.Where("slug IN ?", deletedSlugs).Delete(&Tag{}).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(slugs) > 0 {
+		redis.Redis.ZAdd(autocompleteKey(), 0, slugs...)
+	}
+	if len(deletedSlugs) > 0 {
+		redis.Redis.ZRem(autocompleteKey(), deletedSlugs...)
+	}
+	// 在事务提交后清除接口缓存，避免其他请求在提交前读到旧数据并重新缓存
+	respcache.Invalidate(CacheTag)
+	return tags, nil
+}
+
+func autocompleteKey() string {
+	return config.GetString("app.name") + ":tags:autocomplete"
+}
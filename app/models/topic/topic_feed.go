@@ -0,0 +1,118 @@
+package topic
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/spf13/cast"
+	"gohub/app/models/follow"
+	"gohub/pkg/app"
+	"gohub/pkg/config"
+	"gohub/pkg/database"
+	"gohub/pkg/paginator"
+	"gohub/pkg/redis"
+	"gohub/pkg/sse"
+)
+
+// feed 使用 fan-out-on-write：发布话题时写入每个粉丝的 Redis 有序集合，
+// 成员为话题 ID，分值为发布时间。有序集合只为已读取过 feed 的用户维护，
+// 不存在时（首次读取、关注关系变化、Redis 数据丢失）从数据库重建，
+// Redis 不可用时直接使用 SQL 查询
+
+// PushToFollowers 将话题推送到作者粉丝的 feed，在话题创建成功后调用
+func PushToFollowers(topicModel Topic) {
+	maxLen := config.GetInt64("feed.max_len")
+	member := map[string]float64{topicModel.GetStringID(): feedScore(topicModel)}
+	for _, followerID := range followerIDs(topicModel.UserID) {
+		key := feedKey(followerID)
+		// 未建立 feed 的粉丝在首次读取时从数据库重建，此处跳过
+		if !redis.Redis.Exists(key) {
+			continue
+		}
+		redis.Redis.ZAddScores(key, member)
+		redis.Redis.ZRemRangeByRank(key, 0, -maxLen-1)
+	}
+}
+
+// PublishToFollowers 通过 SSE 将话题动态推送给作者的粉丝，event 如 topic.created
+func PublishToFollowers(event string, topicModel Topic) {
+	data := map[string]interface{}{
+		"id":      topicModel.ID,
+		"title":   topicModel.Title,
+		"user_id": topicModel.UserID,
+	}
+	for _, followerID := range followerIDs(topicModel.UserID) {
+		sse.Publish(followerID, event, data)
+	}
+}
+
+// ForgetFeed 清除用户的 feed，关注或取消关注后调用，下次读取时重建
+func ForgetFeed(userID uint64) {
+	redis.Redis.Del(feedKey(userID))
+}
+
+// PaginateFeed 分页获取 userID 关注的用户发布的话题，新发布的在前
+func PaginateFeed(c *gin.Context, userID uint64, perPage int) (topics []Topic, paging paginator.Paging) {
+	key := feedKey(userID)
+	if !redis.Redis.Exists(key) {
+		rebuildFeed(userID)
+	}
+
+	// Redis 不可用，或用户没有关注任何人发布的话题
+	if !redis.Redis.Exists(key) {
+		paging = paginator.Paginate(
+			c,
+			database.DB.Model(Topic{}).Preload("User").
+				Where("user_id IN (?)", follow.FollowingIDs(userID)).
+				Order("created_at desc"),
+			&topics,
+			app.V1URL("feed"),
+			perPage,
+		)
+		return
+	}
+
+	var ids []uint64
+	paging = paginator.PaginateFunc(c, app.V1URL("feed"), perPage, func(offset, limit int) int64 {
+		members := redis.Redis.ZRevRange(key, int64(offset), int64(offset+limit-1))
+		ids = make([]uint64, len(members))
+		for i, member := range members {
+			ids[i] = cast.ToUint64(member)
+		}
+		return redis.Redis.ZCard(key)
+	})
+	topics = GetByIDs(ids)
+	return
+}
+
+// rebuildFeed 从数据库读取最近的话题，重建用户的 feed
+func rebuildFeed(userID uint64) {
+	var topics []Topic
+	database.DB.Select("id", "created_at").
+		Where("user_id IN (?)", follow.FollowingIDs(userID)).
+		Order("created_at desc").
+		Limit(config.GetInt("feed.max_len")).
+		Find(&topics)
+	if len(topics) == 0 {
+		return
+	}
+
+	scores := make(map[string]float64, len(topics))
+	for _, t := range topics {
+		scores[t.GetStringID()] = feedScore(t)
+	}
+	redis.Redis.ZAddScores(feedKey(userID), scores)
+}
+
+// followerIDs 关注了 userID 的用户 ID
+func followerIDs(userID uint64) (ids []uint64) {
+	follow.FollowerIDs(userID).Pluck("follower_id", &ids)
+	return
+}
+
+// feedScore 话题在 feed 中的分值，使用毫秒级的发布时间
+func feedScore(topicModel Topic) float64 {
+	return float64(topicModel.CreatedAt.UnixMilli())
+}
+
+func feedKey(userID uint64) string {
+	return config.GetString("app.name") + ":feed:" + cast.ToString(userID)
+}
@@ -0,0 +1,12 @@
+package topic
+
+import (
+	"gohub/pkg/markdown"
+	"gorm.io/gorm"
+)
+
+// BeforeSave GORM 的模型钩子，保存话题前渲染 Markdown，同时存储原文和 HTML
+func (topicModel *Topic) BeforeSave(tx *gorm.DB) (err error) {
+	topicModel.BodyHTML = markdown.Render(topicModel.Body)
+	return
+}
@@ -0,0 +1,48 @@
+// Package topic 存放话题 Model 相关逻辑
+package topic
+
+import (
+	"gohub/app/models"
+	"gohub/app/models/tag"
+	"gohub/app/models/user"
+	"gohub/pkg/database"
+	"gohub/pkg/logger"
+	"gohub/pkg/search"
+	"gorm.io/gorm/clause"
+)
+
+// Topic 话题
+type Topic struct {
+	models.BaseModel
+
+	Title    string `gorm:"type:varchar(255);not null" json:"title,omitempty"`
+	Body     string `gorm:"type:longtext;not null" json:"body,omitempty"`
+	BodyHTML string `gorm:"type:longtext" json:"body_html,omitempty"`
+	UserID   uint64 `gorm:"index;not null" json:"user_id,omitempty"`
+
+	// 通过 user_id 关联用户
+	User user.User `json:"user"`
+	// 通过 topic_tags 关联标签，由 tag.SyncTopicTags 维护
+	Tags []tag.Tag `gorm:"many2many:topic_tags" json:"tags"`
+
+	models.CommonTimestampsField
+}
+
+// Create 创建话题，通过 Topic.ID 来判断是否创建成功，不会写入关联的用户和标签
+// 搜索索引在事务提交后同步
+func (topicModel *Topic) Create() {
+	if err := database.DB.Omit(clause.Associations).Create(topicModel).Error; err != nil {
+		logger.LogIf(err)
+		return
+	}
+	logger.LogIf(search.NewSearch().Index(SearchIndex.Name, topicModel.SearchDocument()))
+}
+
+// Save 保存话题，返回影响行数，不会写入关联的用户和标签
+func (topicModel *Topic) Save() (rowsAffected int64) {
+	result := database.DB.Omit(clause.Associations).Save(topicModel)
+	if result.RowsAffected > 0 {
+		logger.LogIf(search.NewSearch().Index(SearchIndex.Name, topicModel.SearchDocument()))
+	}
+	return result.RowsAffected
+}
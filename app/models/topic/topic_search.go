@@ -0,0 +1,23 @@
+package topic
+
+import (
+	"gohub/pkg/search"
+)
+
+// SearchIndex 话题的搜索索引，搜索标题和 Markdown 原文
+var SearchIndex = search.Index{
+	Name:   "topics",
+	Table:  "topics",
+	Fields: []string{"title", "body"},
+}
+
+// SearchDocument 话题对应的搜索文档
+func (topicModel *Topic) SearchDocument() search.Document {
+	return search.Document{
+		ID: topicModel.ID,
+		Fields: map[string]string{
+			"title": topicModel.Title,
+			"body":  topicModel.Body,
+		},
+	}
+}
@@ -0,0 +1,50 @@
+package topic
+
+import (
+	"github.com/gin-gonic/gin"
+	"gohub/app/models/tag"
+	"gohub/pkg/app"
+	"gohub/pkg/database"
+	"gohub/pkg/paginator"
+)
+
+// Get 通过 ID 获取话题，附带作者和标签信息
+func Get(idstr string) (topicModel Topic) {
+	database.DB.Preload("User").Preload("Tags").Where("id", idstr).First(&topicModel)
+	return
+}
+
+// PaginateByTag 分页获取带有该标签的话题，新发布的在前
+func PaginateByTag(c *gin.Context, tagModel tag.Tag, perPage int) (topics []Topic, paging paginator.Paging) {
+	paging = paginator.Paginate(
+		c,
+		database.DB.Model(Topic{}).Preload("User").Preload("Tags").
+			Where("id IN (?)", tag.TopicIDs(tagModel.ID)).
+			Order("created_at desc"),
+		&topics,
+		app.V1URL("tags")+"/"+tagModel.Slug+"/topics",
+		perPage,
+	)
+	return
+}
+
+// GetByIDs 按 ids 的顺序获取话题，附带作者和标签信息
+func GetByIDs(ids []uint64) []Topic {
+	if len(ids) == 0 {
+		return []Topic{}
+	}
+	var topics []Topic
+	database.DB.Preload("User").Preload("Tags").Where("id IN ?", ids).Find(&topics)
+
+	byID := make(map[uint64]Topic, len(topics))
+	for _, t := range topics {
+		byID[t.ID] = t
+	}
+	ordered := make([]Topic, 0, len(ids))
+	for _, id := range ids {
+		if t, ok := byID[id]; ok {
+			ordered = append(ordered, t)
+		}
+	}
+	return ordered
+}
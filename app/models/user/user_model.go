@@ -1,6 +1,12 @@
 package user
 
-import "gohub/app/models"
+import (
+	"gohub/app/models"
+	"gohub/pkg/database"
+	"gohub/pkg/hash"
+	"gohub/pkg/logger"
+	"gohub/pkg/search"
+)
 
 // Package user 存放用户Model相关逻辑
 
@@ -16,3 +22,18 @@ type User struct {
 
 	models.CommonTimestampsField
 }
+
+// Create 创建用户，通过 User.ID 来判断是否创建成功
+// 搜索索引在事务提交后同步，模型钩子在事务内执行，不适合做外部副作用
+func (userModel *User) Create() {
+	if err := database.DB.Create(userModel).Error; err != nil {
+		logger.LogIf(err)
+		return
+	}
+	logger.LogIf(search.NewSearch().Index(SearchIndex.Name, userModel.SearchDocument()))
+}
+
+// ComparePassword 密码是否正确
+func (userModel *User) ComparePassword(_password string) bool {
+	return hash.BcryptCheck(_password, userModel.Password)
+}
@@ -0,0 +1,39 @@
+package user
+
+import (
+	"gohub/pkg/database"
+	"gohub/pkg/search"
+)
+
+// SearchIndex 用户的搜索索引，只开放用户名，手机号和 Email 不参与搜索
+var SearchIndex = search.Index{
+	Name:   "users",
+	Table:  "users",
+	Fields: []string{"name"},
+}
+
+// SearchDocument 用户对应的搜索文档
+func (userModel *User) SearchDocument() search.Document {
+	return search.Document{
+		ID:     userModel.ID,
+		Fields: map[string]string{"name": userModel.Name},
+	}
+}
+
+// GetByIDs 按 ids 的顺序获取用户
+func GetByIDs(ids []uint64) []User {
+	var users []User
+	database.DB.Where("id IN ?", ids).Find(&users)
+
+	byID := make(map[uint64]User, len(users))
+	for _, u := range users {
+		byID[u.ID] = u
+	}
+	ordered := make([]User, 0, len(ids))
+	for _, id := range ids {
+		if u, ok := byID[id]; ok {
+			ordered = append(ordered, u)
+		}
+	}
+	return ordered
+}
@@ -1,6 +1,13 @@
 package user
 
-import "gohub/pkg/database"
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/spf13/cast"
+	"gohub/app/models/follow"
+	"gohub/pkg/app"
+	"gohub/pkg/database"
+	"gohub/pkg/paginator"
+)
 
 // IsEmailExist 判断Email已被注册
 
@@ -16,3 +23,43 @@ func IsPhoneExist(phone string) bool {
 	database.DB.Model(User{}).Where("phone=?", phone).Count(&count)
 	return count > 0
 }
+
+// GetByMulti 通过 手机号/Email/用户名 来获取用户
+func GetByMulti(loginID string) (userModel User) {
+	database.DB.
+		Where("phone = ?", loginID).
+		Or("email = ?", loginID).
+		Or("name = ?", loginID).
+		First(&userModel)
+	return
+}
+
+// Get 通过 ID 获取用户
+func Get(idstr string) (userModel User) {
+	database.DB.Where("id", idstr).First(&userModel)
+	return
+}
+
+// PaginateFollowers 分页获取关注了 userID 的用户
+func PaginateFollowers(c *gin.Context, userID uint64, perPage int) (users []User, paging paginator.Paging) {
+	paging = paginator.Paginate(
+		c,
+		database.DB.Model(User{}).Where("id IN (?)", follow.FollowerIDs(userID)),
+		&users,
+		app.V1URL(database.TableName(&User{}))+"/"+cast.ToString(userID)+"/followers",
+		perPage,
+	)
+	return
+}
+
+// PaginateFollowings 分页获取 userID 关注的用户
+func PaginateFollowings(c *gin.Context, userID uint64, perPage int) (users []User, paging paginator.Paging) {
+	paging = paginator.Paginate(
+		c,
+		database.DB.Model(User{}).Where("id IN (?)", follow.FollowingIDs(userID)),
+		&users,
+		app.V1URL(database.TableName(&User{}))+"/"+cast.ToString(userID)+"/followings",
+		perPage,
+	)
+	return
+}
@@ -0,0 +1,35 @@
+package requests
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/thedevsaddam/govalidator"
+)
+
+// LoginByPasswordRequest 使用密码登录的请求信息
+type LoginByPasswordRequest struct {
+	LoginID  string `valid:"login_id" json:"login_id"`
+	Password string `valid:"password" json:"password,omitempty"`
+}
+
+var (
+	loginByPasswordRules = mustRules(govalidator.MapData{
+		"login_id": []string{"required", "min:3"},
+		"password": []string{"required", "min:6"},
+	})
+
+	loginByPasswordMessages = govalidator.MapData{
+		"login_id": []string{
+			"required:validation.login_id.required",
+			"min:validation.login_id.min",
+		},
+		"password": []string{
+			"required:validation.password.required",
+			"min:validation.password.min",
+		},
+	}
+)
+
+// ValidateLoginByPassword 验证表单，返回长度等于零即通过
+func ValidateLoginByPassword(data interface{}, c *gin.Context) map[string][]string {
+	return validate(data, loginByPasswordRules, loginByPasswordMessages)
+}
@@ -1,8 +1,12 @@
 package requests
 
 import (
+	"errors"
+	"fmt"
+
 	"github.com/gin-gonic/gin"
 	"github.com/thedevsaddam/govalidator"
+	"gohub/app/requests/validators"
 	"gohub/pkg/response"
 )
 
@@ -11,14 +15,20 @@ import (
 // ValidatorFunc 验证函数类型
 type ValidatorFunc func(interface{}, *gin.Context) map[string][]string
 
+// Validate 解析请求并使用 handler 验证，请求结构体中声明的规则通过 StructRules 生成 handler
 func Validate(c *gin.Context, obj interface{}, handler ValidatorFunc) bool {
 	// 解析请求，支持JSON数据、表单请求、URL Query
 	if err := c.ShouldBind(obj); err != nil {
-		response.BadRequest(c, err, "请求解析错误，请确认请求格式是否正确。上传文件使用multipart标头，参数使用JSON格式")
+		response.BadRequest(c, err, "request.bind_error")
 		return false
 	}
 	// 表单验证
 	errs := handler(obj, c)
+	// 查询数据库出错，无法判断数据是否合法，按服务器错误处理
+	if hasCheckFailed(errs) {
+		response.Abort500(c)
+		return false
+	}
 	// 判断验证是否通过
 	if len(errs) > 0 {
 		response.ValidationError(c, errs)
@@ -27,6 +37,7 @@ func Validate(c *gin.Context, obj interface{}, handler ValidatorFunc) bool {
 	return true
 
 }
+
 func validate(data interface{}, rules govalidator.MapData, messages govalidator.MapData) map[string][]string {
 	opts := govalidator.Options{
 		Data:          data,
@@ -37,3 +48,41 @@ func validate(data interface{}, rules govalidator.MapData, messages govalidator.
 
 	return govalidator.New(opts).ValidateStruct()
 }
+
+// mustRules 检查验证规则，规则有误时 panic。
+// 请求的规则声明为包级变量并使用 mustRules 包裹，规则有误时程序在启动时即失败，
+// 而不是在请求时才发现
+func mustRules(rules govalidator.MapData) govalidator.MapData {
+	if err := checkRules(rules); err != nil {
+		panic(err)
+	}
+	return rules
+}
+
+// checkRules 检查验证规则，没有任何规则或规则参数不完整时返回错误
+func checkRules(rules govalidator.MapData) error {
+	// 没有规则时 govalidator 会跳过验证，多半是遗漏了规则，不能静默通过
+	if len(rules) == 0 {
+		return errors.New("requests: no validation rules declared")
+	}
+	for field, fieldRules := range rules {
+		for _, rule := range fieldRules {
+			if err := validators.CheckRule(rule); err != nil {
+				return fmt.Errorf("requests: field %s: %w", field, err)
+			}
+		}
+	}
+	return nil
+}
+
+// hasCheckFailed 验证规则查询数据库时是否出错
+func hasCheckFailed(errs map[string][]string) bool {
+	for _, fieldErrors := range errs {
+		for _, message := range fieldErrors {
+			if message == validators.ErrCheckFailed.Error() {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,33 @@
+package requests
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/thedevsaddam/govalidator"
+)
+
+type SearchRequest struct {
+	Q    string `form:"q" json:"q,omitempty" valid:"q"`
+	Type string `form:"type" json:"type,omitempty" valid:"type"`
+}
+
+var (
+	searchRules = mustRules(govalidator.MapData{
+		"q":    []string{"required", "max:100"},
+		"type": []string{"required", "in:users,topics"},
+	})
+
+	searchMessages = govalidator.MapData{
+		"q": []string{
+			"required:validation.q.required",
+			"max:validation.q.max",
+		},
+		"type": []string{
+			"required:validation.type.required",
+			"in:validation.type.in",
+		},
+	}
+)
+
+func ValidateSearch(data interface{}, c *gin.Context) map[string][]string {
+	return validate(data, searchRules, searchMessages)
+}
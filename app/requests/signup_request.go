@@ -3,6 +3,7 @@ package requests
 import (
 	"github.com/gin-gonic/gin"
 	"github.com/thedevsaddam/govalidator"
+	"gohub/app/requests/validators"
 )
 
 // 处理请求数据和表单验证
@@ -15,35 +16,95 @@ type SignupEmailExistRequest struct {
 	Email string `json:"email,omitempty" valid:"email"`
 }
 
-func ValidateSignupPhoneExist(data interface{}, c *gin.Context) map[string][]string {
+var (
 	// 自定义验证规则
-	rules := govalidator.MapData{
+	signupPhoneExistRules = mustRules(govalidator.MapData{
 		"phone": []string{"required", "digits:11"},
-	}
+	})
 
-	// 自定义验证出错时的提示
-	messages := govalidator.MapData{
+	// 自定义验证出错时的提示，使用语言包中的消息键
+	signupPhoneExistMessages = govalidator.MapData{
 		"phone": []string{
-			"required:手机号为必填项，参数名称phone",
-			"digits:手机号长度必须为11位的数字",
+			"required:validation.phone.required",
+			"digits:validation.phone.digits",
 		},
 	}
+)
 
-	return validate(data, rules, messages)
+func ValidateSignupPhoneExist(data interface{}, c *gin.Context) map[string][]string {
+	return validate(data, signupPhoneExistRules, signupPhoneExistMessages)
 }
 
-func ValidateSignupEmailExist(data interface{}, c *gin.Context) map[string][]string {
-	rules := govalidator.MapData{
+var (
+	signupEmailExistRules = mustRules(govalidator.MapData{
 		"email": []string{"required", "min:4", "max:30", "email"},
-	}
+	})
 
-	messages := govalidator.MapData{
+	signupEmailExistMessages = govalidator.MapData{
 		"email": []string{
-			"required:Email为必填项",
-			"min:Email长度需要大于4",
-			"max:Email长度需小于30",
-			"email:Email格式不正确，请提供有效的邮箱地址",
+			"required:validation.email.required",
+			"min:validation.email.min",
+			"max:validation.email.max",
+			"email:validation.email.email",
 		},
 	}
-	return validate(data, rules, messages)
+)
+
+func ValidateSignupEmailExist(data interface{}, c *gin.Context) map[string][]string {
+	return validate(data, signupEmailExistRules, signupEmailExistMessages)
+}
+
+// SignupUsingPhoneRequest 通过手机注册的请求信息
+type SignupUsingPhoneRequest struct {
+	Phone           string `json:"phone,omitempty" valid:"phone"`
+	VerifyCode      string `json:"verify_code,omitempty" valid:"verify_code"`
+	Name            string `valid:"name" json:"name"`
+	Password        string `valid:"password" json:"password,omitempty"`
+	PasswordConfirm string `valid:"password_confirm" json:"password_confirm,omitempty"`
+}
+
+var (
+	signupUsingPhoneRules = mustRules(govalidator.MapData{
+		"phone":            []string{"required", "digits:11", "not_exists:users,phone"},
+		"name":             []string{"required", "alpha_num", "between:3,20", "not_exists:users,name", "no_sensitive_words"},
+		"password":         []string{"required", "min:6"},
+		"password_confirm": []string{"required"},
+		"verify_code":      []string{"required", "digits:6"},
+	})
+
+	signupUsingPhoneMessages = govalidator.MapData{
+		"phone": []string{
+			"required:validation.phone.required",
+			"digits:validation.phone.digits",
+			"not_exists:validation.phone.registered",
+		},
+		"name": []string{
+			"required:validation.name.required",
+			"alpha_num:validation.name.alpha_num",
+			"between:validation.name.between",
+			"not_exists:validation.name.taken",
+			"no_sensitive_words:validation.name.sensitive",
+		},
+		"password": []string{
+			"required:validation.password.required",
+			"min:validation.password.min",
+		},
+		"password_confirm": []string{
+			"required:validation.password_confirm.required",
+		},
+		"verify_code": []string{
+			"required:validation.verify_code.required",
+			"digits:validation.verify_code.digits",
+		},
+	}
+)
+
+func ValidateSignupUsingPhone(data interface{}, c *gin.Context) map[string][]string {
+	errs := validate(data, signupUsingPhoneRules, signupUsingPhoneMessages)
+
+	_data := data.(*SignupUsingPhoneRequest)
+	errs = validators.ValidatePasswordConfirm(_data.Password, _data.PasswordConfirm, errs)
+	errs = validators.ValidateVerifyCode(_data.Phone, _data.VerifyCode, errs)
+
+	return errs
 }
@@ -0,0 +1,104 @@
+package requests
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/thedevsaddam/govalidator"
+)
+
+// 除了为每个请求编写 ValidatorFunc，也可以在请求结构体中直接声明验证规则，
+// 再使用 StructRules 生成 ValidatorFunc：
+//
+//	var ValidateSignupPhoneExist = requests.StructRules(SignupPhoneExistRequest{})
+//
+// 支持两种写法：
+//
+// 1. 结构体标签，多个规则和消息使用 | 分隔：
+//
+//	type SignupPhoneExistRequest struct {
+//		Phone string `json:"phone,omitempty" valid:"phone" rules:"required|digits:11" messages:"required:validation.phone.required|digits:validation.phone.digits"`
+//	}
+//
+// 2. 实现 RulesProvider 和 MessagesProvider，适合规则需要在代码中拼接的情况
+
+// RulesProvider 请求结构体通过 Rules 方法声明验证规则
+type RulesProvider interface {
+	Rules() govalidator.MapData
+}
+
+// MessagesProvider 请求结构体通过 Messages 方法声明验证出错时的提示
+type MessagesProvider interface {
+	Messages() govalidator.MapData
+}
+
+const (
+	// rulesTag 声明验证规则的结构体标签
+	rulesTag = "rules"
+	// messagesTag 声明验证出错提示的结构体标签
+	messagesTag = "messages"
+	// tagSeparator 标签中多个规则的分隔符，规则参数中会用到逗号，故使用 |
+	tagSeparator = "|"
+)
+
+// StructRules 读取请求结构体中声明的规则，返回使用这些规则验证的 ValidatorFunc。
+// 规则只在调用时解析一次，应赋值给包级变量，没有声明规则或规则有误时程序在启动时 panic
+func StructRules(obj interface{}) ValidatorFunc {
+	rules, messages := govalidator.MapData{}, govalidator.MapData{}
+	parseStructTags(reflect.TypeOf(obj), rules, messages)
+
+	// 方法声明的规则优先于标签
+	if provider, ok := obj.(RulesProvider); ok {
+		for field, fieldRules := range provider.Rules() {
+			rules[field] = fieldRules
+		}
+	}
+	if provider, ok := obj.(MessagesProvider); ok {
+		for field, fieldMessages := range provider.Messages() {
+			messages[field] = fieldMessages
+		}
+	}
+
+	if err := checkRules(rules); err != nil {
+		panic(fmt.Sprintf("requests: %T: %v", obj, err))
+	}
+	return func(data interface{}, c *gin.Context) map[string][]string {
+		return validate(data, rules, messages)
+	}
+}
+
+// parseStructTags 读取结构体标签中的规则，包括匿名嵌入的结构体
+// 字段名称与 govalidator 保持一致：优先使用 valid 标签，否则为『结构体名.字段名』
+func parseStructTags(t reflect.Type, rules, messages govalidator.MapData) {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return
+	}
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.Anonymous {
+			parseStructTags(field.Type, rules, messages)
+			continue
+		}
+
+		fieldRules := field.Tag.Get(rulesTag)
+		if len(fieldRules) == 0 {
+			continue
+		}
+
+		name := t.Name() + "." + field.Name
+		if valid := strings.Split(field.Tag.Get("valid"), ",")[0]; len(valid) > 0 && valid != "-" {
+			name = valid
+		}
+
+		rules[name] = strings.Split(fieldRules, tagSeparator)
+		if fieldMessages := field.Tag.Get(messagesTag); len(fieldMessages) > 0 {
+			messages[name] = strings.Split(fieldMessages, tagSeparator)
+		}
+	}
+}
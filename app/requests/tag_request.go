@@ -0,0 +1,27 @@
+package requests
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/thedevsaddam/govalidator"
+)
+
+type TagAutocompleteRequest struct {
+	Prefix string `form:"prefix" json:"prefix,omitempty" valid:"prefix"`
+}
+
+var (
+	tagAutocompleteRules = mustRules(govalidator.MapData{
+		"prefix": []string{"required", "max:64"},
+	})
+
+	tagAutocompleteMessages = govalidator.MapData{
+		"prefix": []string{
+			"required:validation.prefix.required",
+			"max:validation.prefix.max",
+		},
+	}
+)
+
+func ValidateTagAutocomplete(data interface{}, c *gin.Context) map[string][]string {
+	return validate(data, tagAutocompleteRules, tagAutocompleteMessages)
+}
@@ -0,0 +1,45 @@
+package requests
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/thedevsaddam/govalidator"
+	"gohub/app/requests/validators"
+)
+
+// TopicRequest 创建和更新话题的请求信息
+type TopicRequest struct {
+	Title string `json:"title,omitempty" valid:"title"`
+	Body  string `json:"body,omitempty" valid:"body"`
+	// 标签名，更新时不传则保持原有标签，传空数组则清空
+	Tags []string `json:"tags,omitempty" valid:"tags"`
+}
+
+var (
+	topicSaveRules = mustRules(govalidator.MapData{
+		"title": []string{"required", "min_cn:3", "max_cn:40", "no_sensitive_words"},
+		"body":  []string{"required", "min_cn:10", "max_cn:50000"},
+	})
+
+	topicSaveMessages = govalidator.MapData{
+		"title": []string{
+			"required:validation.title.required",
+			"min_cn:validation.title.min",
+			"max_cn:validation.title.max",
+			"no_sensitive_words:validation.title.sensitive",
+		},
+		"body": []string{
+			"required:validation.body.required",
+			"min_cn:validation.body.min",
+			"max_cn:validation.body.max",
+		},
+	}
+)
+
+func ValidateTopicSave(data interface{}, c *gin.Context) map[string][]string {
+	errs := validate(data, topicSaveRules, topicSaveMessages)
+
+	_data := data.(*TopicRequest)
+	errs = validators.ValidateTags(_data.Tags, errs)
+
+	return errs
+}
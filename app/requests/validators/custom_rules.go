@@ -0,0 +1,178 @@
+// Package validators 存放自定义规则和验证器
+package validators
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/spf13/cast"
+	"github.com/thedevsaddam/govalidator"
+	"gohub/pkg/database"
+	"gohub/pkg/filter"
+	"gohub/pkg/i18n"
+	"gohub/pkg/logger"
+)
+
+// ErrCheckFailed exists、not_exists 查询数据库出错时返回的错误，此时无法判断数据是否合法，
+// 不属于验证错误，requests.Validate 遇到该错误时响应 500
+var ErrCheckFailed = errors.New("validators: database check failed")
+
+// dbRules 需要查询数据库的规则，参数至少包含表名和字段名
+var dbRules = []string{"not_exists", "exists"}
+
+// CheckRule 检查规则的参数是否完整，规则有误属于代码问题，不能作为验证错误返回给客户端
+func CheckRule(rule string) error {
+	for _, name := range dbRules {
+		if rule != name && !strings.HasPrefix(rule, name+":") {
+			continue
+		}
+		rng := strings.Split(strings.TrimPrefix(rule, name+":"), ",")
+		if len(rng) < 2 || len(rng[0]) == 0 || len(rng[1]) == 0 {
+			return fmt.Errorf("validators: rule %q needs table and field, e.g. %s:users,phone", rule, name)
+		}
+	}
+	return nil
+}
+
+// notExistsMessages 未指定消息时 not_exists 使用的消息键，
+// 手机号和 Email 已被注册时返回对应的错误码 phone_registered、email_registered
+var notExistsMessages = map[string]string{
+	"phone": "validation.phone.registered",
+	"email": "validation.email.registered",
+}
+
+// 此方法会在初始化时执行，注册自定义表单验证规则
+func init() {
+	// 自定义规则 not_exists，验证请求数据必须不存在于数据库中。
+	// 常用于保证数据库某个字段的值唯一，如用户名、邮箱、手机号、或者分类的名称。
+	// not_exists 参数可以有两种，一种是 2 个参数，一种是 3 个参数：
+	// not_exists:users,email 检查数据库表里是否存在同一条信息
+	// not_exists:users,email,32 排除掉 id 为 32 的记录，用于更新数据时
+	govalidator.AddCustomRule("not_exists", func(field string, rule string, message string, value interface{}) error {
+		// 声明规则时已由 CheckRule 检查参数，至少包含表名和字段名
+		rng := strings.Split(strings.TrimPrefix(rule, "not_exists:"), ",")
+
+		// 第一个参数，表名称，如 users
+		tableName := rng[0]
+		// 第二个参数，字段名称，如 email 或者 phone
+		dbField := rng[1]
+
+		// 第三个参数，排除 ID
+		var exceptID string
+		if len(rng) > 2 {
+			exceptID = rng[2]
+		}
+
+		// 用户请求过来的数据
+		requestValue := cast.ToString(value)
+
+		// 拼接 SQL
+		query := database.DB.Table(tableName).Where(dbField+" = ?", requestValue)
+
+		// 如果传参第三个参数，加上 SQL Where 过滤
+		if len(exceptID) > 0 {
+			query = query.Where("id != ?", exceptID)
+		}
+
+		// 查询数据库，查询出错时无法确认数据唯一
+		var count int64
+		if err := query.Count(&count).Error; err != nil {
+			logger.LogIf(err)
+			return ErrCheckFailed
+		}
+
+		// 验证不通过，数据库能找到对应的数据
+		if count != 0 {
+			// 如果有自定义错误消息的话
+			if message != "" {
+				return errors.New(message)
+			}
+			if key, ok := notExistsMessages[dbField]; ok {
+				return errors.New(key)
+			}
+			// 默认的错误消息
+			return errors.New(i18n.Message("validation.not_exists", requestValue))
+		}
+		// 验证通过
+		return nil
+	})
+
+	// 自定义规则 exists，确保数据库存在某条数据
+	// 一个使用场景是创建话题时需要附带 category_id 分类 ID 为参数，此时需要保证
+	// category_id 的值在数据库中存在，即可使用：
+	// exists:categories,id
+	govalidator.AddCustomRule("exists", func(field string, rule string, message string, value interface{}) error {
+		// 声明规则时已由 CheckRule 检查参数，至少包含表名和字段名
+		rng := strings.Split(strings.TrimPrefix(rule, "exists:"), ",")
+
+		// 第一个参数，表名称，如 categories
+		tableName := rng[0]
+		// 第二个参数，字段名称，如 id
+		dbField := rng[1]
+
+		// 用户请求过来的数据
+		requestValue := cast.ToString(value)
+
+		// 查询数据库，查询出错时无法确认数据存在
+		var count int64
+		if err := database.DB.Table(tableName).Where(dbField+" = ?", requestValue).Count(&count).Error; err != nil {
+			logger.LogIf(err)
+			return ErrCheckFailed
+		}
+
+		// 验证不通过，数据不存在
+		if count == 0 {
+			// 如果有自定义错误消息的话
+			if message != "" {
+				return errors.New(message)
+			}
+			return errors.New(i18n.Message("validation.exists", requestValue))
+		}
+		return nil
+	})
+
+	// 自定义规则 no_sensitive_words，内容不能包含敏感词
+	// no_sensitive_words 使用 reject 模式，适用于用户名等不能替换的内容
+	govalidator.AddCustomRule("no_sensitive_words", func(field string, rule string, message string, value interface{}) error {
+		if !filter.NewFilter().Contains(cast.ToString(value)) {
+			return nil
+		}
+		if message != "" {
+			return errors.New(message)
+		}
+		return errors.New(i18n.Message("validation.no_sensitive_words", field))
+	})
+
+	// 自定义规则 max_cn，按字符数而非字节数计算长度，中文算一个字符
+	// max_cn:8 中文长度设定不超过 8
+	govalidator.AddCustomRule("max_cn", func(field string, rule string, message string, value interface{}) error {
+		valLength := utf8.RuneCountInString(cast.ToString(value))
+		l, _ := strconv.Atoi(strings.TrimPrefix(rule, "max_cn:"))
+		if valLength > l {
+			// 如果有自定义错误消息的话，使用自定义消息
+			if message != "" {
+				return errors.New(message)
+			}
+			return errors.New(i18n.Message("validation.max_cn", field, l))
+		}
+		return nil
+	})
+
+	// 自定义规则 min_cn，按字符数而非字节数计算长度，中文算一个字符
+	// min_cn:2 中文长度设定不小于 2
+	govalidator.AddCustomRule("min_cn", func(field string, rule string, message string, value interface{}) error {
+		valLength := utf8.RuneCountInString(cast.ToString(value))
+		l, _ := strconv.Atoi(strings.TrimPrefix(rule, "min_cn:"))
+		if valLength < l {
+			// 如果有自定义错误消息的话，使用自定义消息
+			if message != "" {
+				return errors.New(message)
+			}
+			return errors.New(i18n.Message("validation.min_cn", field, l))
+		}
+		return nil
+	})
+}
@@ -0,0 +1,58 @@
+package validators
+
+import (
+	"unicode/utf8"
+
+	"gohub/pkg/captcha"
+	"gohub/pkg/config"
+	"gohub/pkg/i18n"
+	"gohub/pkg/verifycode"
+)
+
+// 存放自定义验证器，在 ValidatorFunc 中调用 validate() 之后组合使用，
+// 验证不通过时将语言包中的消息键追加到 errs，例如：
+//
+//	errs := validate(data, rules, messages)
+//	errs = validators.ValidateCaptcha(_data.CaptchaID, _data.CaptchaAnswer, errs)
+//	return errs
+
+// ValidateCaptcha 自定义规则，验证『图片验证码』
+func ValidateCaptcha(captchaID, captchaAnswer string, errs map[string][]string) map[string][]string {
+	if ok := captcha.NewCaptcha().VerifyCaptcha(captchaID, captchaAnswer); !ok {
+		errs["captcha_answer"] = append(errs["captcha_answer"], "validation.captcha_answer.invalid")
+	}
+	return errs
+}
+
+// ValidatePasswordConfirm 自定义规则，检查两次密码是否正确
+func ValidatePasswordConfirm(password, passwordConfirm string, errs map[string][]string) map[string][]string {
+	if password != passwordConfirm {
+		errs["password_confirm"] = append(errs["password_confirm"], "validation.password_confirm.mismatch")
+	}
+	return errs
+}
+
+// ValidateVerifyCode 自定义规则，验证『手机/邮箱验证码』
+func ValidateVerifyCode(key, answer string, errs map[string][]string) map[string][]string {
+	if ok := verifycode.NewVerifyCode().CheckAnswer(key, answer); !ok {
+		errs["verify_code"] = append(errs["verify_code"], "validation.verify_code.invalid")
+	}
+	return errs
+}
+
+// ValidateTags 校验话题的标签数量和每个标签的长度，供话题的创建和更新请求使用
+func ValidateTags(tags []string, errs map[string][]string) map[string][]string {
+	maxCount := config.GetInt("tag.max_count")
+	maxLength := config.GetInt("tag.max_length")
+
+	if len(tags) > maxCount {
+		errs["tags"] = append(errs["tags"], i18n.Message("validation.tags.max_count", maxCount))
+	}
+	for _, name := range tags {
+		if length := utf8.RuneCountInString(name); length == 0 || length > maxLength {
+			errs["tags"] = append(errs["tags"], i18n.Message("validation.tags.length", maxLength))
+			break
+		}
+	}
+	return errs
+}
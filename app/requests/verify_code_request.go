@@ -0,0 +1,48 @@
+package requests
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/thedevsaddam/govalidator"
+	"gohub/app/requests/validators"
+)
+
+// VerifyCodePhoneRequest 发送短信验证码的请求信息
+type VerifyCodePhoneRequest struct {
+	CaptchaID     string `json:"captcha_id,omitempty" valid:"captcha_id"`
+	CaptchaAnswer string `json:"captcha_answer,omitempty" valid:"captcha_answer"`
+
+	Phone string `json:"phone,omitempty" valid:"phone"`
+}
+
+var (
+	verifyCodePhoneRules = mustRules(govalidator.MapData{
+		"phone":          []string{"required", "digits:11"},
+		"captcha_id":     []string{"required"},
+		"captcha_answer": []string{"required", "digits:6"},
+	})
+
+	verifyCodePhoneMessages = govalidator.MapData{
+		"phone": []string{
+			"required:validation.phone.required",
+			"digits:validation.phone.digits",
+		},
+		"captcha_id": []string{
+			"required:validation.captcha_id.required",
+		},
+		"captcha_answer": []string{
+			"required:validation.captcha_answer.required",
+			"digits:validation.captcha_answer.digits",
+		},
+	}
+)
+
+// ValidateVerifyCodePhone 验证表单，返回长度等于零即通过
+func ValidateVerifyCodePhone(data interface{}, c *gin.Context) map[string][]string {
+	errs := validate(data, verifyCodePhoneRules, verifyCodePhoneMessages)
+
+	// 图片验证码
+	_data := data.(*VerifyCodePhoneRequest)
+	errs = validators.ValidateCaptcha(_data.CaptchaID, _data.CaptchaAnswer, errs)
+
+	return errs
+}
@@ -5,9 +5,14 @@ import (
 	"time"
 
 	"github.com/pkg/errors"
+	"gohub/app/models/follow"
+	"gohub/app/models/notification"
+	"gohub/app/models/tag"
+	"gohub/app/models/topic"
 	"gohub/app/models/user"
 	"gohub/pkg/database"
 	"gohub/pkg/logger"
+	"gohub/pkg/tracing"
 	"gorm.io/driver/sqlite"
 
 	"gohub/pkg/config"
@@ -44,6 +49,10 @@ func SetupDB() {
 	// 连接数据库，并设置GORM的日志模式
 	// database.Connect(dbConfig, logger.Default.LogMode(logger.Info))
 	database.Connect(dbConfig, logger.NewGormLogger())
+	// 开启追踪时，为每条 SQL 创建 span
+	if tracing.Enabled() {
+		logger.LogIf(database.RegisterTracing())
+	}
 
 	// 设置最大连接数
 	database.SQLDB.SetMaxOpenConns(config.GetInt("database.mysql.max_open_connections"))
@@ -53,5 +62,5 @@ func SetupDB() {
 
 	// 设置每个连接过期时间
 	database.SQLDB.SetConnMaxLifetime(time.Duration(config.GetInt("database.mysql.max_life_seconds")) * time.Second)
-	database.DB.AutoMigrate(&user.User{})
+	database.DB.AutoMigrate(&user.User{}, &follow.Follow{}, &notification.Notification{}, &tag.Tag{}, &tag.TopicTag{}, &topic.Topic{})
 }
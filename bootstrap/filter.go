@@ -0,0 +1,15 @@
+package bootstrap
+
+import (
+	"gohub/pkg/config"
+	"gohub/pkg/filter"
+	"gohub/pkg/logger"
+)
+
+// SetupFilter 加载敏感词库，并监控词库文件的变更
+func SetupFilter() {
+	filename := config.GetString("filter.words_file")
+	f := filter.NewFilter()
+	logger.LogIf(f.Load(filename))
+	logger.LogIf(f.Watch(filename))
+}
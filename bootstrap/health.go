@@ -0,0 +1,26 @@
+package bootstrap
+
+import (
+	"context"
+	"errors"
+
+	"gohub/pkg/database"
+	"gohub/pkg/health"
+	"gohub/pkg/redis"
+)
+
+// SetupHealth 注册 /readyz 需要检查的依赖，数据库和 Redis 都是关键依赖
+func SetupHealth() {
+	health.Register("database", true, func(ctx context.Context) error {
+		if database.SQLDB == nil {
+			return errors.New("database not connected")
+		}
+		return database.SQLDB.PingContext(ctx)
+	})
+	health.Register("redis", true, func(ctx context.Context) error {
+		if redis.Redis == nil {
+			return errors.New("redis not connected")
+		}
+		return redis.Redis.WithContext(ctx).Ping()
+	})
+}
@@ -0,0 +1,13 @@
+package bootstrap
+
+import (
+	"gohub/pkg/config"
+	"gohub/pkg/i18n"
+)
+
+// SetupI18n 加载语言包，语言包缺失时无法返回可读的错误消息，直接退出
+func SetupI18n() {
+	if err := i18n.Load(config.GetString("i18n.path"), config.GetString("i18n.default_locale")); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,15 @@
+package bootstrap
+
+import (
+	"gohub/pkg/config"
+	"gohub/pkg/database"
+	"gohub/pkg/metrics"
+)
+
+// SetupMetrics 注册数据库连接池指标
+func SetupMetrics() {
+	if !config.GetBool("metrics.enabled") || database.SQLDB == nil {
+		return
+	}
+	metrics.RegisterDB(database.SQLDB, config.GetString("database.connection"))
+}
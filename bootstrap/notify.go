@@ -0,0 +1,20 @@
+package bootstrap
+
+import (
+	"gohub/app/models/notification"
+	"gohub/pkg/notify"
+	"gohub/pkg/sse"
+	"gohub/pkg/ws"
+)
+
+// SetupNotify 注册站内通知渠道
+func SetupNotify() {
+	notify.NewDispatcher().Use(
+		&notification.DatabaseChannel{},
+		&ws.RedisChannel{},
+		&sse.StreamChannel{},
+	)
+
+	// 订阅其他实例发布的通知，推送给本实例的 WebSocket 连接
+	go ws.Subscribe()
+}
@@ -7,33 +7,79 @@ import (
 	"strings"
 
 	"github.com/gin-gonic/gin"
+	"github.com/spf13/cast"
 	"gohub/app/http/middlewares"
+	"gohub/pkg/app"
+	"gohub/pkg/config"
+	"gohub/pkg/errcode"
+	"gohub/pkg/i18n"
+	"gohub/pkg/metrics"
+	"gohub/pkg/tracing"
 	"gohub/routes"
 )
 
 func SetupRoute(router *gin.Engine) {
 	// setupRoute 路由初始化
 	registerGlobalMiddleWare(router)
+	setupMetricsRoute(router)
 	routes.RegisterAPIRoutes(router)
 	setup404Handler(router)
 }
 
 func registerGlobalMiddleWare(router *gin.Engine) {
+	router.Use(
+		middlewares.RequestID(),
+	)
+	// 追踪需在 Logger 之前，访问日志才能带上 trace_id
+	if tracing.Enabled() {
+		router.Use(middlewares.Tracing())
+	}
 	router.Use(
 		middlewares.Logger(),
-		middlewares.Recovery(),
 	)
+	// 指标需在 Recovery 之前，panic 恢复后的 500 才会被统计
+	if config.GetBool("metrics.enabled") {
+		router.Use(middlewares.Metrics())
+	}
+	router.Use(middlewares.Recovery())
+	// 安全响应头需在 CORS 之前，预检请求的响应同样带上
+	if securityEnabled("security.headers") {
+		router.Use(middlewares.SecureHeaders())
+	}
+	// 跨域请求，预检请求在此返回
+	router.Use(middlewares.CORS())
+	// 拒绝没有 User-Agent 的请求
+	if securityEnabled("security.force_user_agent") {
+		router.Use(middlewares.ForceUA())
+	}
+}
+
+// securityEnabled 安全相关的开关，未通过环境变量设置时只在生产环境开启
+func securityEnabled(key string) bool {
+	if value := config.Get(key); len(value) > 0 {
+		return cast.ToBool(value)
+	}
+	return app.IsProduction()
+}
+
+// setupMetricsRoute 暴露 Prometheus 指标
+func setupMetricsRoute(router *gin.Engine) {
+	if config.GetBool("metrics.enabled") {
+		router.GET(config.GetString("metrics.path"), middlewares.MetricsAuth(), metrics.Handler())
+	}
 }
 
 func setup404Handler(router *gin.Engine) {
 	router.NoRoute(func(c *gin.Context) {
 		acceptString := c.Request.Header.Get("Accept")
 		if strings.Contains(acceptString, "text/html") {
-			c.String(http.StatusNotFound, "页面返回404")
+			c.String(http.StatusNotFound, i18n.Trans(c, "route.not_found_html"))
 		} else {
 			c.JSON(http.StatusNotFound, gin.H{
 				"error_code":    404,
-				"error_message": "路由未定义，请确认url和请求方法是否正确。",
+				"error_message": i18n.Trans(c, "route.not_found"),
+				"code":          errcode.RouteNotFound.Code,
+				"key":           errcode.RouteNotFound.Key,
 			})
 		}
 	})
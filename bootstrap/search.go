@@ -0,0 +1,21 @@
+package bootstrap
+
+import (
+	"gohub/app/models/topic"
+	"gohub/app/models/user"
+	"gohub/pkg/config"
+	"gohub/pkg/logger"
+	"gohub/pkg/search"
+)
+
+// SetupSearch 注册可搜索的数据表，local 驱动从数据库加载索引
+func SetupSearch() {
+	s := search.NewSearch()
+	s.Register(user.SearchIndex)
+	s.Register(topic.SearchIndex)
+
+	if config.Get("search.driver") == "local" {
+		logger.LogIf(s.Rebuild(user.SearchIndex.Name))
+		logger.LogIf(s.Rebuild(topic.SearchIndex.Name))
+	}
+}
@@ -0,0 +1,79 @@
+package bootstrap
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+	"gohub/app/http/middlewares"
+	"gohub/pkg/config"
+	"gohub/pkg/database"
+	"gohub/pkg/logger"
+	"gohub/pkg/redis"
+	"gohub/pkg/tracing"
+	"gohub/pkg/ws"
+)
+
+// RunServer 根据配置启动 HTTP 服务，收到 SIGINT/SIGTERM 后优雅关闭
+func RunServer(router *gin.Engine) {
+	srv := &http.Server{
+		Addr:              ":" + config.Get("app.port"),
+		Handler:           router,
+		ReadTimeout:       seconds("server.read_timeout"),
+		ReadHeaderTimeout: seconds("server.read_header_timeout"),
+		WriteTimeout:      seconds("server.write_timeout"),
+		IdleTimeout:       seconds("server.idle_timeout"),
+		MaxHeaderBytes:    config.GetInt("server.max_header_bytes"),
+		// SSE、WebSocket 路由通过 middlewares.Streaming 取消写超时
+		ConnContext: middlewares.ConnContext,
+	}
+	// Shutdown 不会中断长连接，需主动关闭，否则会一直等到宽限期结束
+	srv.RegisterOnShutdown(middlewares.CloseStreams)
+	srv.RegisterOnShutdown(ws.NewHub().CloseAll)
+
+	go func() {
+		if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			logger.Fatal("Server", zap.Error(err))
+		}
+	}()
+	logger.InfoString("Server", "Listen", srv.Addr)
+
+	// 阻塞直到收到退出信号
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
+	sig := <-quit
+	logger.InfoString("Server", "Shutdown", sig.String())
+
+	// 停止接收新连接，并在宽限期内等待进行中的请求结束
+	ctx, cancel := context.WithTimeout(context.Background(), seconds("server.shutdown_timeout"))
+	defer cancel()
+	if err := srv.Shutdown(ctx); err != nil {
+		logger.ErrorString("Server", "Shutdown", err.Error())
+	}
+
+	closeResources()
+}
+
+// closeResources 按顺序导出剩余的 span，释放数据库、Redis 连接，最后刷新日志
+func closeResources() {
+	// 单独给导出 span 留出时间，不受请求宽限期是否用尽的影响
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	logger.LogIf(tracing.Shutdown(ctx))
+	logger.LogIf(database.Close())
+	if redis.Redis != nil {
+		logger.LogIf(redis.Redis.Close())
+	}
+	logger.InfoString("Server", "Exit", "bye")
+	_ = logger.Sync()
+}
+
+func seconds(key string) time.Duration {
+	return time.Duration(config.GetInt64(key)) * time.Second
+}
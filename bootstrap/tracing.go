@@ -0,0 +1,32 @@
+package bootstrap
+
+import (
+	"strings"
+
+	"gohub/pkg/config"
+	"gohub/pkg/logger"
+	"gohub/pkg/tracing"
+)
+
+// SetupTracing 初始化分布式追踪，需在 SetupDB 和 SetupRedis 之前调用
+func SetupTracing() {
+	if !config.GetBool("tracing.enabled") {
+		return
+	}
+
+	headers := make(map[string]string)
+	for _, pair := range strings.Split(config.GetString("tracing.otlp_headers"), ",") {
+		if key, value, ok := strings.Cut(pair, "="); ok {
+			headers[strings.TrimSpace(key)] = strings.TrimSpace(value)
+		}
+	}
+
+	logger.LogIf(tracing.Init(tracing.Options{
+		ServiceName: config.GetString("app.name"),
+		Exporter:    config.GetString("tracing.exporter"),
+		FilePath:    config.GetString("tracing.file"),
+		Endpoint:    config.GetString("tracing.otlp_endpoint"),
+		Headers:     headers,
+		SampleRatio: config.GetFloat64("tracing.sample_ratio"),
+	}))
+}
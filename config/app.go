@@ -7,7 +7,9 @@ func init() {
 		return map[string]interface{}{
 			// 应用名称
 			"name": config.Env("APP_NAME", "Gohub"),
-			// 当前环境
+			// 当前环境，用以区分多环境，一般为 local, testing, production，未设置时不属于任何环境
+			"env": config.Env("APP_ENV", ""),
+			// 是否进入调试模式
 			"debug": config.Env("APP_DEBUG", false),
 
 			// 应用服务端口
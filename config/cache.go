@@ -0,0 +1,12 @@
+package config
+
+import "gohub/pkg/config"
+
+func init() {
+	config.Add("cache", func() map[string]interface{} {
+		return map[string]interface{}{
+			// 使用 CacheResponse 中间件的接口的缓存时间，单位是秒，0 为不缓存（仍会返回 ETag）
+			"response_ttl": config.Env("CACHE_RESPONSE_TTL", 60),
+		}
+	})
+}
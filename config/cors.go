@@ -0,0 +1,23 @@
+package config
+
+import "gohub/pkg/config"
+
+func init() {
+	config.Add("cors", func() map[string]interface{} {
+		return map[string]interface{}{
+			// 允许跨域访问的来源，多个用逗号分隔，默认为空即不允许跨域，* 允许所有来源，
+			// 支持通配子域名，如 https://*.example.com
+			"allowed_origins": config.Env("CORS_ALLOWED_ORIGINS", ""),
+			// 允许的请求方法
+			"allowed_methods": config.Env("CORS_ALLOWED_METHODS", "GET,POST,PUT,PATCH,DELETE,OPTIONS"),
+			// 允许的请求头
+			"allowed_headers": config.Env("CORS_ALLOWED_HEADERS", "Origin,Content-Type,Accept,Accept-Language,Authorization,X-Request-ID,Idempotency-Key"),
+			// 允许前端读取的响应头
+			"exposed_headers": config.Env("CORS_EXPOSED_HEADERS", "X-Request-ID,Idempotent-Replayed,ETag"),
+			// 是否允许携带 Cookie 等凭证，开启后来源不能配置为 *，需列出具体的来源
+			"allow_credentials": config.Env("CORS_ALLOW_CREDENTIALS", false),
+			// 预检请求结果的缓存时间，单位是秒
+			"max_age": config.Env("CORS_MAX_AGE", 43200),
+		}
+	})
+}
@@ -0,0 +1,12 @@
+package config
+
+import "gohub/pkg/config"
+
+func init() {
+	config.Add("feed", func() map[string]interface{} {
+		return map[string]interface{}{
+			// 每个用户的 feed 在 Redis 中最多保留的话题数，超出时丢弃最早的话题
+			"max_len": config.Env("FEED_MAX_LEN", 500),
+		}
+	})
+}
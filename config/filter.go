@@ -0,0 +1,16 @@
+package config
+
+import "gohub/pkg/config"
+
+func init() {
+	config.Add("filter", func() map[string]interface{} {
+		return map[string]interface{}{
+			// 敏感词库文件，每行一个词，# 开头为注释，修改后自动重新加载
+			"words_file": config.Env("FILTER_WORDS_FILE", "storage/filter/words.txt"),
+			// 处理模式，reject 拒绝提交，mask 替换为 mask_char
+			"mode": config.Env("FILTER_MODE", "reject"),
+			// mask 模式下的替换字符
+			"mask_char": "*",
+		}
+	})
+}
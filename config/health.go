@@ -0,0 +1,12 @@
+package config
+
+import "gohub/pkg/config"
+
+func init() {
+	config.Add("health", func() map[string]interface{} {
+		return map[string]interface{}{
+			// 单个依赖检查的超时时间，单位是毫秒
+			"timeout": config.Env("HEALTH_TIMEOUT", 2000),
+		}
+	})
+}
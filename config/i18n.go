@@ -0,0 +1,16 @@
+package config
+
+import "gohub/pkg/config"
+
+func init() {
+	config.Add("i18n", func() map[string]interface{} {
+		return map[string]interface{}{
+			// 默认语言，请求未指定或不支持时使用
+			"default_locale": config.Env("I18N_DEFAULT_LOCALE", "zh-CN"),
+			// 语言包目录，每个语言一个 JSON 文件，如 zh-CN.json
+			"path": config.Env("I18N_PATH", "resources/lang"),
+			// URL 中指定语言的参数，优先于 Accept-Language 请求头
+			"url_query_locale": "lang",
+		}
+	})
+}
@@ -0,0 +1,16 @@
+package config
+
+import "gohub/pkg/config"
+
+func init() {
+	config.Add("idempotency", func() map[string]interface{} {
+		return map[string]interface{}{
+			// 首次请求的响应保留的时间，单位是秒，期间相同 Idempotency-Key 的请求直接重放该响应
+			"ttl": config.Env("IDEMPOTENCY_TTL", 86400),
+			// 首次请求处理中的锁定时间，单位是秒，应大于接口的最长处理时间
+			"lock_ttl": config.Env("IDEMPOTENCY_LOCK_TTL", 60),
+			// 带 Idempotency-Key 的请求 body 的最大长度，单位是字节，超过时返回 413
+			"max_body_size": config.Env("IDEMPOTENCY_MAX_BODY_SIZE", 1048576),
+		}
+	})
+}
@@ -0,0 +1,16 @@
+package config
+
+import "gohub/pkg/config"
+
+func init() {
+	config.Add("jwt", func() map[string]interface{} {
+		return map[string]interface{}{
+			// 签名密钥，默认使用 app.key
+			"key": config.Env("JWT_KEY", ""),
+			// 过期时间，单位是分钟
+			"expire_time": config.Env("JWT_EXPIRE_TIME", 120),
+			// 本地开发环境的过期时间，方便调试
+			"debug_expire_time": 86400,
+		}
+	})
+}
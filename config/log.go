@@ -20,6 +20,15 @@ func init() {
 			"max_age": config.Env("LOG_MAX_AGE", 30),
 			// 是否压缩,设置为false不压缩
 			"compress": config.Env("LOG_COMPRESS", false),
+			// 不记录访问日志的路径，多个用逗号分隔，避免探针请求刷屏
+			"access_skip_paths": config.Env("LOG_ACCESS_SKIP_PATHS", "/healthz,/readyz,/metrics"),
+			// ---------------------请求日志脱敏---------------------------
+			// 请求和响应 JSON、表单中需要脱敏的字段，多个用逗号分隔，不区分大小写
+			"redact_keys": config.Env("LOG_REDACT_KEYS", "password,password_confirm,verify_code,token,captcha_answer"),
+			// 需要脱敏的请求头，多个用逗号分隔
+			"redact_headers": config.Env("LOG_REDACT_HEADERS", "Authorization,Cookie"),
+			// 请求和响应 body 最多记录的字节数，超出部分截断，0 为不限制
+			"max_body_size": config.Env("LOG_MAX_BODY_SIZE", 2048),
 		}
 	})
 }
@@ -0,0 +1,16 @@
+package config
+
+import "gohub/pkg/config"
+
+func init() {
+	config.Add("metrics", func() map[string]interface{} {
+		return map[string]interface{}{
+			// 是否开启 Prometheus 指标，默认关闭，开启后建议同时设置 token
+			"enabled": config.Env("METRICS_ENABLED", false),
+			// 指标的访问路径
+			"path": config.Env("METRICS_PATH", "/metrics"),
+			// 访问令牌，设置后抓取请求需附带 Authorization: Bearer <token>，留空则不校验（仅限内网暴露时）
+			"token": config.Env("METRICS_TOKEN", ""),
+		}
+	})
+}
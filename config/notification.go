@@ -0,0 +1,12 @@
+package config
+
+import "gohub/pkg/config"
+
+func init() {
+	config.Add("notification", func() map[string]interface{} {
+		return map[string]interface{}{
+			// 未读通知数缓存时间，单位是分钟
+			"unread_count_expire_time": config.Env("NOTIFICATION_UNREAD_COUNT_EXPIRE", 60),
+		}
+	})
+}
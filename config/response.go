@@ -0,0 +1,17 @@
+package config
+
+import "gohub/pkg/config"
+
+func init() {
+	config.Add("response", func() map[string]interface{} {
+		return map[string]interface{}{
+			// 是否使用统一的成功响应格式 {success, data, meta, request_id}
+			// meta 中包含 code、key、分页信息和处理耗时，方便前端统一解析
+			"envelope": config.Env("RESPONSE_ENVELOPE", false),
+
+			// 是否支持 RFC 7807 problem details，开启后请求头
+			// Accept 包含 application/problem+json 的错误响应使用该格式
+			"problem_details": config.Env("RESPONSE_PROBLEM_DETAILS", false),
+		}
+	})
+}
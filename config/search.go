@@ -0,0 +1,20 @@
+package config
+
+import "gohub/pkg/config"
+
+func init() {
+	config.Add("search", func() map[string]interface{} {
+		return map[string]interface{}{
+			// 搜索驱动，支持 sql 和 local
+			// sql 直接查询数据表，local 使用内嵌的倒排索引，适合 SQLite 部署。
+			// local 的索引保存在进程内存中，只能单实例部署：多个实例时，其他实例写入的数据搜索不到
+			"driver": config.Env("SEARCH_DRIVER", "sql"),
+
+			// MySQL 下使用 FULLTEXT 索引查询，需先为搜索字段建立 FULLTEXT 索引
+			"mysql_fulltext": config.Env("SEARCH_MYSQL_FULLTEXT", false),
+
+			// 高亮片段中关键词前后保留的字数
+			"snippet_radius": 30,
+		}
+	})
+}
@@ -0,0 +1,24 @@
+package config
+
+import "gohub/pkg/config"
+
+func init() {
+	config.Add("security", func() map[string]interface{} {
+		return map[string]interface{}{
+			// 是否拒绝没有 User-Agent 的请求，未设置时生产环境开启，其他环境关闭
+			"force_user_agent": config.Env("SECURITY_FORCE_USER_AGENT"),
+			// 是否添加安全相关的响应头（以下各项），未设置时生产环境开启，其他环境关闭
+			"headers": config.Env("SECURITY_HEADERS"),
+			// X-Frame-Options，禁止页面被嵌入 iframe
+			"frame_options": config.Env("SECURITY_FRAME_OPTIONS", "DENY"),
+			// Referrer-Policy
+			"referrer_policy": config.Env("SECURITY_REFERRER_POLICY", "strict-origin-when-cross-origin"),
+			// Content-Security-Policy，留空则不发送。接口只返回 JSON，默认禁止加载任何资源
+			"content_security_policy": config.Env("SECURITY_CSP", "default-src 'none'; frame-ancestors 'none'"),
+			// HSTS 的有效期，单位是秒，只在 HTTPS 请求且非本地环境时发送，0 为不发送
+			"hsts_max_age": config.Env("SECURITY_HSTS_MAX_AGE", 31536000),
+			// HSTS 是否包含子域名
+			"hsts_include_subdomains": config.Env("SECURITY_HSTS_INCLUDE_SUBDOMAINS", true),
+		}
+	})
+}
@@ -0,0 +1,22 @@
+package config
+
+import "gohub/pkg/config"
+
+func init() {
+	config.Add("server", func() map[string]interface{} {
+		return map[string]interface{}{
+			// 读取整个请求（含 body）的超时时间，单位是秒
+			"read_timeout": config.Env("SERVER_READ_TIMEOUT", 15),
+			// 读取请求头的超时时间，单位是秒，防止慢速攻击
+			"read_header_timeout": config.Env("SERVER_READ_HEADER_TIMEOUT", 5),
+			// 写响应的超时时间，单位是秒，SSE 和 WebSocket 路由不受此限制
+			"write_timeout": config.Env("SERVER_WRITE_TIMEOUT", 30),
+			// keep-alive 连接的空闲超时时间，单位是秒
+			"idle_timeout": config.Env("SERVER_IDLE_TIMEOUT", 60),
+			// 请求头的最大字节数，默认 1MB
+			"max_header_bytes": config.Env("SERVER_MAX_HEADER_BYTES", 1<<20),
+			// 收到 SIGINT/SIGTERM 后等待进行中请求结束的时间，单位是秒
+			"shutdown_timeout": config.Env("SERVER_SHUTDOWN_TIMEOUT", 10),
+		}
+	})
+}
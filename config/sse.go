@@ -0,0 +1,16 @@
+package config
+
+import "gohub/pkg/config"
+
+func init() {
+	config.Add("sse", func() map[string]interface{} {
+		return map[string]interface{}{
+			// 每个用户的事件 stream 最多保留的条数，超过后丢弃最早的事件
+			"max_len": config.Env("SSE_MAX_LEN", 1000),
+			// 心跳间隔，单位是秒，防止代理因连接空闲而断开
+			"heartbeat": config.Env("SSE_HEARTBEAT", 25),
+			// Redis 出错断开连接时，建议客户端重连的等待时间，单位是毫秒
+			"retry": config.Env("SSE_RETRY", 3000),
+		}
+	})
+}
@@ -0,0 +1,19 @@
+package config
+
+import "gohub/pkg/config"
+
+func init() {
+	config.Add("tag", func() map[string]interface{} {
+		return map[string]interface{}{
+			// 每个话题最多的标签数
+			"max_count": config.Env("TAG_MAX_COUNT", 5),
+			// 标签名最大长度，按字数计算
+			"max_length": config.Env("TAG_MAX_LENGTH", 20),
+			// 自动补全返回的条数
+			"autocomplete_limit": 10,
+			// 自动补全时按字典序取出的候选标签数，从中选出使用次数最多的 autocomplete_limit 个，
+			// 前缀匹配的标签超过该数量时，排在字典序后面的热门标签可能不会出现
+			"autocomplete_candidates": 200,
+		}
+	})
+}
@@ -0,0 +1,22 @@
+package config
+
+import "gohub/pkg/config"
+
+func init() {
+	config.Add("tracing", func() map[string]interface{} {
+		return map[string]interface{}{
+			// 是否开启分布式追踪
+			"enabled": config.Env("TRACING_ENABLED", false),
+			// 导出方式，stdout 输出到终端，file 写入文件，otlp 上报到 OTLP/HTTP 服务
+			"exporter": config.Env("TRACING_EXPORTER", "stdout"),
+			// exporter 为 file 时写入的文件
+			"file": config.Env("TRACING_FILE", "storage/logs/traces.log"),
+			// exporter 为 otlp 时的上报地址
+			"otlp_endpoint": config.Env("TRACING_OTLP_ENDPOINT", "http://localhost:4318/v1/traces"),
+			// 上报时附带的请求头，格式为 key1=value1,key2=value2
+			"otlp_headers": config.Env("TRACING_OTLP_HEADERS", ""),
+			// 采样比例，0 到 1
+			"sample_ratio": config.Env("TRACING_SAMPLE_RATIO", 1.0),
+		}
+	})
+}
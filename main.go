@@ -2,7 +2,6 @@ package main
 
 import (
 	"flag"
-	"fmt"
 
 	"github.com/gin-gonic/gin"
 	"gohub/bootstrap"
@@ -21,14 +20,23 @@ func main() {
 	config.InitConfig(env)
 	// 初始化Logger
 	bootstrap.SetupLogger()
+	// 加载语言包
+	bootstrap.SetupI18n()
 	// 设置 gin 的运行模式，支持 debug, release, test
 	// release 会屏蔽调试信息，官方建议生产环境中使用
 	// 非 release 模式 gin 终端打印太多信息，干扰到我们程序中的 Log
 	// 故此设置为 release，有特殊情况手动改为 debug 即可
 	gin.SetMode(gin.ReleaseMode)
+	// 初始化追踪，DB 和 Redis 的 span 依赖于此
+	bootstrap.SetupTracing()
 	// 初始化DB
 	bootstrap.SetupDB()
 	bootstrap.SetupRedis()
+	bootstrap.SetupHealth()
+	bootstrap.SetupMetrics()
+	bootstrap.SetupNotify()
+	bootstrap.SetupSearch()
+	bootstrap.SetupFilter()
 	router := gin.New()
 	bootstrap.SetupRoute(router)
 	/*
@@ -42,9 +50,6 @@ func main() {
 	})
 	*/
 	verifycode.NewVerifyCode().SendSMS("13250324304")
-	err := router.Run(":" + config.Get("app.port"))
-	if err != nil {
-		fmt.Println(err.Error())
-	}
-
+	// 启动服务，收到退出信号后优雅关闭
+	bootstrap.RunServer(router)
 }
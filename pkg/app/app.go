@@ -1,6 +1,10 @@
 package app
 
-import "gohub/pkg/config"
+import (
+	"time"
+
+	"gohub/pkg/config"
+)
 
 func IsLocal() bool {
 	return config.Get("app.env") == "local"
@@ -12,3 +16,19 @@ func IsProduction() bool {
 func IsTesting() bool {
 	return config.Get("app.env") == "testing"
 }
+
+// TimenowInTimezone 获取当前时间，支持时区
+func TimenowInTimezone() time.Time {
+	chinaTimezone, _ := time.LoadLocation(config.GetString("app.timezone"))
+	return time.Now().In(chinaTimezone)
+}
+
+// URL 传参 path 拼接站点的 URL
+func URL(path string) string {
+	return config.Get("app.url") + path
+}
+
+// V1URL 拼接带 v1 标示 URL
+func V1URL(path string) string {
+	return URL("/v1/" + path)
+}
@@ -0,0 +1,40 @@
+// Package auth 授权相关逻辑
+package auth
+
+import (
+	"errors"
+
+	"github.com/gin-gonic/gin"
+	"github.com/spf13/cast"
+	"gohub/app/models/user"
+	"gohub/pkg/logger"
+)
+
+// Attempt 尝试登录，loginID 可以是手机号、Email 或用户名
+func Attempt(loginID string, password string) (user.User, error) {
+	userModel := user.GetByMulti(loginID)
+	if userModel.ID == 0 {
+		return user.User{}, errors.New("账号不存在")
+	}
+
+	if !userModel.ComparePassword(password) {
+		return user.User{}, errors.New("密码错误")
+	}
+
+	return userModel, nil
+}
+
+// CurrentUser 从 gin.context 中获取当前登录用户
+func CurrentUser(c *gin.Context) user.User {
+	userModel, ok := c.MustGet("current_user").(user.User)
+	if !ok {
+		logger.LogIf(errors.New("无法获取用户"))
+		return user.User{}
+	}
+	return userModel
+}
+
+// CurrentUID 从 gin.context 中获取当前登录用户 ID
+func CurrentUID(c *gin.Context) uint64 {
+	return cast.ToUint64(c.GetString("current_user_id"))
+}
@@ -31,3 +31,18 @@ func Connect(dbConfig gorm.Dialector, _logger gormlogger.Interface) {
 		fmt.Println(err.Error())
 	}
 }
+
+// TableName 获取模型对应的数据表名称
+func TableName(obj interface{}) string {
+	stmt := &gorm.Statement{DB: DB}
+	stmt.Parse(obj)
+	return stmt.Schema.Table
+}
+
+// Close 关闭数据库连接池
+func Close() error {
+	if SQLDB == nil {
+		return nil
+	}
+	return SQLDB.Close()
+}
@@ -0,0 +1,37 @@
+package errcode
+
+import "net/http"
+
+// 通用错误码，与 pkg/response 中的响应方法对应
+var (
+	OK                      = Register(0, "ok", http.StatusOK, "response.success")
+	BadRequest              = Register(40000, "bad_request", http.StatusBadRequest, "response.bad_request")
+	UserAgentMissing        = Register(40001, "user_agent_missing", http.StatusBadRequest, "request.user_agent_missing")
+	IdempotencyKeyInvalid   = Register(40002, "idempotency_key_invalid", http.StatusBadRequest, "idempotency.key_invalid")
+	Unauthorized            = Register(40100, "unauthorized", http.StatusUnauthorized, "response.unauthorized")
+	Forbidden               = Register(40300, "forbidden", http.StatusForbidden, "response.forbidden")
+	NotFound                = Register(40400, "not_found", http.StatusNotFound, "response.not_found")
+	RouteNotFound           = Register(40401, "route_not_found", http.StatusNotFound, "route.not_found")
+	IdempotencyInProgress   = Register(40900, "idempotency_in_progress", http.StatusConflict, "idempotency.in_progress")
+	IdempotencyBodyTooLarge = Register(41300, "idempotency_body_too_large", http.StatusRequestEntityTooLarge, "idempotency.body_too_large")
+	ValidationFailed        = Register(42200, "validation_failed", http.StatusUnprocessableEntity, "response.validation_error")
+	RequestFailed           = Register(42201, "request_failed", http.StatusUnprocessableEntity, "response.error")
+	IdempotencyKeyReused    = Register(42202, "idempotency_key_reused", http.StatusUnprocessableEntity, "idempotency.key_reused")
+	InternalError           = Register(50000, "internal_error", http.StatusInternalServerError, "response.internal_error")
+)
+
+// 业务错误码，1 开头的 5 位数字
+var (
+	VerifyCodeInvalid       = Register(10001, "verify_code_invalid", http.StatusUnprocessableEntity, "validation.verify_code.invalid")
+	CaptchaInvalid          = Register(10002, "captcha_invalid", http.StatusUnprocessableEntity, "validation.captcha_answer.invalid")
+	PasswordConfirmMismatch = Register(10003, "password_confirm_mismatch", http.StatusUnprocessableEntity, "validation.password_confirm.mismatch")
+	PhoneRegistered         = Register(10004, "phone_registered", http.StatusUnprocessableEntity, "validation.phone.registered")
+	EmailRegistered         = Register(10005, "email_registered", http.StatusUnprocessableEntity, "validation.email.registered")
+	TagsTooMany             = Register(10006, "tags_too_many", http.StatusUnprocessableEntity, "validation.tags.max_count")
+	TagLengthInvalid        = Register(10007, "tag_length_invalid", http.StatusUnprocessableEntity, "validation.tags.length")
+	SearchFailed            = Register(10008, "search_failed", http.StatusInternalServerError, "search.failed")
+	FollowSelf              = Register(10009, "follow_self", http.StatusUnprocessableEntity, "follow.self")
+	LoginFailed             = Register(10010, "login_failed", http.StatusUnauthorized, "auth.login_failed")
+	TokenExpired            = Register(10011, "token_expired", http.StatusUnauthorized, "auth.token_expired")
+	TokenInvalid            = Register(10012, "token_invalid", http.StatusUnauthorized, "auth.token_invalid")
+)
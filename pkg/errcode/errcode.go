@@ -0,0 +1,67 @@
+// Package errcode 应用级错误码，客户端通过 code 或 key 区分错误，无需解析 message
+package errcode
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// Code 错误码
+type Code struct {
+	Code       int    `json:"code"`        // 数字错误码，全局唯一
+	Key        string `json:"key"`         // 机器可读的错误标识，全局唯一
+	Status     int    `json:"status"`      // 对应的 HTTP 状态码
+	MessageKey string `json:"message_key"` // 语言包中的消息键
+}
+
+var (
+	mu sync.RWMutex
+	// byCode 数字错误码 => Code
+	byCode = map[int]Code{}
+	// byMessageKey 语言包消息键 => Code，用于为验证消息匹配错误码
+	byMessageKey = map[string]Code{}
+)
+
+// Register 注册错误码，code 或 key 重复时 panic，应在 init 中调用
+func Register(code int, key string, status int, messageKey string) Code {
+	mu.Lock()
+	defer mu.Unlock()
+
+	if existing, ok := byCode[code]; ok {
+		panic(fmt.Sprintf("errcode: code %d already registered as %s", code, existing.Key))
+	}
+	for _, existing := range byCode {
+		if existing.Key == key {
+			panic(fmt.Sprintf("errcode: key %s already registered as %d", key, existing.Code))
+		}
+	}
+
+	c := Code{Code: code, Key: key, Status: status, MessageKey: messageKey}
+	byCode[code] = c
+	if len(messageKey) > 0 {
+		byMessageKey[messageKey] = c
+	}
+	return c
+}
+
+// ByMessageKey 通过语言包消息键查找错误码
+func ByMessageKey(messageKey string) (Code, bool) {
+	mu.RLock()
+	defer mu.RUnlock()
+	c, ok := byMessageKey[messageKey]
+	return c, ok
+}
+
+// All 所有已注册的错误码，按 code 排序
+func All() []Code {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	codes := make([]Code, 0, len(byCode))
+	for _, c := range byCode {
+		codes = append(codes, c)
+	}
+	sort.Slice(codes, func(i, j int) bool { return codes[i].Code < codes[j].Code })
+	return codes
+}
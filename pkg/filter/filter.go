@@ -0,0 +1,145 @@
+// Package filter 敏感词过滤
+package filter
+
+import (
+	"bufio"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/fsnotify/fsnotify"
+	"gohub/pkg/config"
+	"gohub/pkg/logger"
+)
+
+// ErrSensitive 内容包含敏感词
+var ErrSensitive = errors.New("内容包含敏感词")
+
+// Filter 敏感词过滤操作类
+type Filter struct {
+	// 当前使用的 *Matcher，重新加载词库时整体替换
+	matcher atomic.Value
+}
+
+// once 单例模式
+var once sync.Once
+
+// internalFilter 内部使用的 Filter 对象
+var internalFilter *Filter
+
+// NewFilter 单例模式获取，初始为空词库
+func NewFilter() *Filter {
+	once.Do(func() {
+		internalFilter = &Filter{}
+		internalFilter.matcher.Store(NewMatcher(nil))
+	})
+	return internalFilter
+}
+
+// Load 从词库文件加载敏感词，每行一个，# 开头为注释
+func (f *Filter) Load(filename string) error {
+	file, err := os.Open(filename)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	var words []string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		word := strings.TrimSpace(scanner.Text())
+		if len(word) == 0 || strings.HasPrefix(word, "#") {
+			continue
+		}
+		words = append(words, word)
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+
+	f.matcher.Store(NewMatcher(words))
+	logger.InfoString("Filter", "Load", "敏感词库已加载："+filename)
+	return nil
+}
+
+// Watch 监控词库文件，变更时重新加载
+// 监控所在目录，以兼容编辑器先删除再创建文件的保存方式
+func (f *Filter) Watch(filename string) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+	if err := watcher.Add(filepath.Dir(filename)); err != nil {
+		watcher.Close()
+		return err
+	}
+
+	target := filepath.Clean(filename)
+	go func() {
+		defer watcher.Close()
+		for {
+			select {
+			case event, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+				if filepath.Clean(event.Name) != target {
+					continue
+				}
+				if event.Op&(fsnotify.Write|fsnotify.Create|fsnotify.Rename) != 0 {
+					logger.LogIf(f.Load(filename))
+				}
+			case err, ok := <-watcher.Errors:
+				if !ok {
+					return
+				}
+				logger.LogIf(err)
+			}
+		}
+	}()
+	return nil
+}
+
+// Contains 判断内容是否包含敏感词
+func (f *Filter) Contains(text string) bool {
+	return len(f.get().FindAll(text)) > 0
+}
+
+// Mask 将敏感词替换为 filter.mask_char
+func (f *Filter) Mask(text string) string {
+	matches := f.get().FindAll(text)
+	if len(matches) == 0 {
+		return text
+	}
+
+	runes := []rune(text)
+	mask := []rune(config.GetString("filter.mask_char"))
+	if len(mask) == 0 {
+		mask = []rune{'*'}
+	}
+	for _, m := range matches {
+		for i := m.Start; i < m.End; i++ {
+			runes[i] = mask[0]
+		}
+	}
+	return string(runes)
+}
+
+// Apply 按 filter.mode 处理内容
+// reject 模式下包含敏感词返回 ErrSensitive，mask 模式下返回替换后的内容
+func (f *Filter) Apply(text string) (string, error) {
+	if config.Get("filter.mode") == "mask" {
+		return f.Mask(text), nil
+	}
+	if f.Contains(text) {
+		return text, ErrSensitive
+	}
+	return text, nil
+}
+
+func (f *Filter) get() *Matcher {
+	return f.matcher.Load().(*Matcher)
+}
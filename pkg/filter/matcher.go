@@ -0,0 +1,98 @@
+package filter
+
+import "unicode"
+
+// node Aho-Corasick 自动机的节点
+type node struct {
+	children map[rune]*node
+	fail     *node
+	// 以此节点结尾的敏感词长度（按字数），包含 fail 链上的敏感词
+	outputs []int
+}
+
+// Matcher 基于 Aho-Corasick 的多模式匹配器，构建后只读，可并发使用
+type Matcher struct {
+	root *node
+}
+
+// Match 一次命中，Start 和 End 为字符（rune）下标，左闭右开
+type Match struct {
+	Start int
+	End   int
+}
+
+// NewMatcher 由敏感词列表构建匹配器，忽略大小写
+func NewMatcher(words []string) *Matcher {
+	root := &node{children: make(map[rune]*node)}
+
+	// 构建 Trie
+	for _, word := range words {
+		runes := normalize(word)
+		if len(runes) == 0 {
+			continue
+		}
+		cur := root
+		for _, r := range runes {
+			next, ok := cur.children[r]
+			if !ok {
+				next = &node{children: make(map[rune]*node)}
+				cur.children[r] = next
+			}
+			cur = next
+		}
+		cur.outputs = append(cur.outputs, len(runes))
+	}
+
+	// 广度优先构建 fail 指针
+	queue := make([]*node, 0, len(root.children))
+	for _, child := range root.children {
+		child.fail = root
+		queue = append(queue, child)
+	}
+	for len(queue) > 0 {
+		cur := queue[0]
+		queue = queue[1:]
+		for r, child := range cur.children {
+			fail := cur.fail
+			for fail != nil && fail.children[r] == nil {
+				fail = fail.fail
+			}
+			if fail == nil {
+				child.fail = root
+			} else {
+				child.fail = fail.children[r]
+			}
+			child.outputs = append(child.outputs, child.fail.outputs...)
+			queue = append(queue, child)
+		}
+	}
+
+	return &Matcher{root: root}
+}
+
+// FindAll 返回文本中所有命中的位置
+func (m *Matcher) FindAll(text string) []Match {
+	var matches []Match
+	cur := m.root
+	for i, r := range normalize(text) {
+		for cur != m.root && cur.children[r] == nil {
+			cur = cur.fail
+		}
+		if next, ok := cur.children[r]; ok {
+			cur = next
+		}
+		for _, length := range cur.outputs {
+			matches = append(matches, Match{Start: i - length + 1, End: i + 1})
+		}
+	}
+	return matches
+}
+
+// normalize 统一转小写，保持字符数不变
+func normalize(s string) []rune {
+	runes := []rune(s)
+	for i, r := range runes {
+		runes[i] = unicode.ToLower(r)
+	}
+	return runes
+}
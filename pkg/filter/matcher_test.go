@@ -0,0 +1,34 @@
+package filter
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMatcherFindAll(t *testing.T) {
+	tests := []struct {
+		name  string
+		words []string
+		text  string
+		want  []Match
+	}{
+		{"相互重叠", []string{"abc", "bcd"}, "abcd", []Match{{0, 3}, {1, 4}}},
+		{"自身重叠", []string{"aa"}, "aaaa", []Match{{0, 2}, {1, 3}, {2, 4}}},
+		{"嵌套与后缀", []string{"he", "she", "his", "hers"}, "ushers", []Match{{1, 4}, {2, 4}, {2, 6}}},
+		{"中文嵌套", []string{"中国", "中国人", "国人"}, "我是中国人", []Match{{2, 4}, {2, 5}, {3, 5}}},
+		{"德文大小写", []string{"äpfel"}, "ÄPFEL und Äpfel", []Match{{0, 5}, {10, 15}}},
+		{"希腊文大写", []string{"σοφια"}, "ΣΟΦΙΑ", []Match{{0, 5}}},
+		{"敏感词为大写", []string{"ПРИВЕТ"}, "привет, Привет", []Match{{0, 6}, {8, 14}}},
+		{"中英混合，下标按字符计算", []string{"golang"}, "我爱GoLang", []Match{{2, 8}}},
+		{"不做改变字数的转换", []string{"straße"}, "STRASSE", nil},
+		{"空敏感词被忽略", []string{""}, "abc", nil},
+		{"空词库", nil, "abc", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewMatcher(tt.words).FindAll(tt.text); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("FindAll(%q) = %v, want %v", tt.text, got, tt.want)
+			}
+		})
+	}
+}
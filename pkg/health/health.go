@@ -0,0 +1,99 @@
+// Package health 依赖健康检查，供 /readyz 探针使用
+package health
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"gohub/pkg/helpers"
+)
+
+// CheckFunc 检查一个依赖是否可用，需遵守 ctx 的超时
+type CheckFunc func(ctx context.Context) error
+
+// Result 单个依赖的检查结果
+type Result struct {
+	Status   string `json:"status"`
+	Latency  string `json:"latency"`
+	Critical bool   `json:"critical"`
+	Error    string `json:"error,omitempty"`
+}
+
+type checker struct {
+	name     string
+	critical bool
+	check    CheckFunc
+}
+
+var (
+	mu       sync.RWMutex
+	checkers []checker
+)
+
+// Register 注册一个依赖检查，critical 为 true 时该依赖不可用会导致未就绪
+func Register(name string, critical bool, check CheckFunc) {
+	mu.Lock()
+	defer mu.Unlock()
+	checkers = append(checkers, checker{name: name, critical: critical, check: check})
+}
+
+// Check 并发执行所有检查，每项检查最多等待 timeout，
+// 返回各依赖的结果，以及所有关键依赖是否都可用
+func Check(ctx context.Context, timeout time.Duration) (map[string]Result, bool) {
+	mu.RLock()
+	list := make([]checker, len(checkers))
+	copy(list, checkers)
+	mu.RUnlock()
+
+	results := make(map[string]Result, len(list))
+	ready := true
+	var (
+		wg       sync.WaitGroup
+		resultMu sync.Mutex
+	)
+	for _, ck := range list {
+		wg.Add(1)
+		go func(ck checker) {
+			defer wg.Done()
+			result := run(ctx, ck, timeout)
+
+			resultMu.Lock()
+			defer resultMu.Unlock()
+			results[ck.name] = result
+			if ck.critical && result.Status != "up" {
+				ready = false
+			}
+		}(ck)
+	}
+	wg.Wait()
+	return results, ready
+}
+
+func run(ctx context.Context, ck checker, timeout time.Duration) Result {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	// 部分客户端不完全遵守 ctx 的超时，这里再兜底一次，避免探针被卡住
+	start := time.Now()
+	done := make(chan error, 1)
+	go func() {
+		done <- ck.check(ctx)
+	}()
+	var err error
+	select {
+	case err = <-done:
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+	result := Result{
+		Status:   "up",
+		Latency:  helpers.MicrosecondStr(time.Since(start)),
+		Critical: ck.critical,
+	}
+	if err != nil {
+		result.Status = "down"
+		result.Error = err.Error()
+	}
+	return result
+}
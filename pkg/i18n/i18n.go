@@ -0,0 +1,193 @@
+// Package i18n 处理多语言消息
+package i18n
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+	"github.com/spf13/cast"
+	"gohub/pkg/config"
+)
+
+// contextKey 当前请求的语言在 gin.Context 中的键名
+const contextKey = "locale"
+
+// argSeparator Message 编码时消息键和参数之间的分隔符
+const argSeparator = "\x00"
+
+var (
+	mu sync.RWMutex
+	// catalogs 语言 => 消息键 => 消息
+	catalogs = map[string]map[string]string{}
+)
+
+// Load 加载目录下的语言包，文件名即语言，如 zh-CN.json
+// 目录下没有语言包，或缺少 defaultLocale 的语言包时返回错误
+func Load(dir string, defaultLocale string) error {
+	files, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return err
+	}
+	if len(files) == 0 {
+		return fmt.Errorf("i18n: no language catalogs found in %s", dir)
+	}
+
+	loaded := make(map[string]map[string]string, len(files))
+	for _, file := range files {
+		content, err := os.ReadFile(file)
+		if err != nil {
+			return err
+		}
+		messages := map[string]string{}
+		if err := json.Unmarshal(content, &messages); err != nil {
+			return fmt.Errorf("%s: %w", file, err)
+		}
+		loaded[strings.TrimSuffix(filepath.Base(file), ".json")] = messages
+	}
+	if _, ok := loaded[defaultLocale]; !ok {
+		return fmt.Errorf("i18n: default locale catalog %s.json not found in %s", defaultLocale, dir)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	catalogs = loaded
+	return nil
+}
+
+// Message 将消息键和参数编码为一个字符串，用于只能传递字符串的场景，如表单验证的错误消息，
+// T 和 Trans 会解码并使用参数格式化，参数均转为字符串，语言包中使用 %v 占位
+func Message(key string, args ...interface{}) string {
+	parts := make([]string, 0, len(args)+1)
+	parts = append(parts, key)
+	for _, arg := range args {
+		parts = append(parts, cast.ToString(arg))
+	}
+	return strings.Join(parts, argSeparator)
+}
+
+// Key 返回 Message 编码的消息中的消息键，普通消息原样返回
+func Key(message string) string {
+	return strings.SplitN(message, argSeparator, 2)[0]
+}
+
+// T 获取 locale 语言的消息，找不到时依次使用默认语言和 key 本身
+// 传参 args 或 key 由 Message 编码时使用 fmt.Sprintf 格式化
+func T(locale, key string, args ...interface{}) string {
+	if strings.Contains(key, argSeparator) {
+		parts := strings.Split(key, argSeparator)
+		key = parts[0]
+		encoded := make([]interface{}, 0, len(parts)-1+len(args))
+		for _, part := range parts[1:] {
+			encoded = append(encoded, part)
+		}
+		args = append(encoded, args...)
+	}
+
+	mu.RLock()
+	message, ok := catalogs[locale][key]
+	if !ok {
+		message, ok = catalogs[config.GetString("i18n.default_locale")][key]
+	}
+	mu.RUnlock()
+
+	if !ok {
+		message = key
+	}
+	if len(args) > 0 {
+		return fmt.Sprintf(message, args...)
+	}
+	return message
+}
+
+// Trans 获取当前请求语言的消息
+func Trans(c *gin.Context, key string, args ...interface{}) string {
+	return T(Locale(c), key, args...)
+}
+
+// Locale 当前请求的语言，依次取 URL 参数、Accept-Language 请求头、默认语言
+func Locale(c *gin.Context) string {
+	if locale, ok := c.Get(contextKey); ok {
+		return cast.ToString(locale)
+	}
+
+	locale := match(c.Query(config.GetString("i18n.url_query_locale")))
+	if len(locale) == 0 {
+		for _, tag := range parseAcceptLanguage(c.GetHeader("Accept-Language")) {
+			if locale = match(tag); len(locale) > 0 {
+				break
+			}
+		}
+	}
+	if len(locale) == 0 {
+		locale = config.GetString("i18n.default_locale")
+	}
+
+	c.Set(contextKey, locale)
+	return locale
+}
+
+// match 匹配已加载的语言，先完全匹配（忽略大小写），再按主语言匹配，如 en-US 匹配 en，zh 匹配 zh-CN
+func match(tag string) string {
+	tag = strings.TrimSpace(tag)
+	if len(tag) == 0 || tag == "*" {
+		return ""
+	}
+
+	mu.RLock()
+	defer mu.RUnlock()
+
+	locales := make([]string, 0, len(catalogs))
+	for locale := range catalogs {
+		if strings.EqualFold(locale, tag) {
+			return locale
+		}
+		locales = append(locales, locale)
+	}
+	sort.Strings(locales)
+
+	base := strings.ToLower(strings.SplitN(tag, "-", 2)[0])
+	for _, locale := range locales {
+		if strings.ToLower(strings.SplitN(locale, "-", 2)[0]) == base {
+			return locale
+		}
+	}
+	return ""
+}
+
+// parseAcceptLanguage 解析 Accept-Language 请求头，按权重从高到低返回语言
+func parseAcceptLanguage(header string) []string {
+	type weighted struct {
+		tag string
+		q   float64
+	}
+
+	var tags []weighted
+	for _, part := range strings.Split(header, ",") {
+		pieces := strings.Split(strings.TrimSpace(part), ";")
+		if len(pieces[0]) == 0 {
+			continue
+		}
+		q := 1.0
+		for _, param := range pieces[1:] {
+			if param = strings.TrimSpace(param); strings.HasPrefix(param, "q=") {
+				q = cast.ToFloat64(strings.TrimPrefix(param, "q="))
+			}
+		}
+		if q > 0 {
+			tags = append(tags, weighted{tag: pieces[0], q: q})
+		}
+	}
+	sort.SliceStable(tags, func(i, j int) bool { return tags[i].q > tags[j].q })
+
+	result := make([]string, len(tags))
+	for i, t := range tags {
+		result[i] = t.tag
+	}
+	return result
+}
@@ -0,0 +1,159 @@
+// Package jwt 处理 JWT 认证
+package jwt
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	jwtpkg "github.com/golang-jwt/jwt/v4"
+	"gohub/pkg/app"
+	"gohub/pkg/config"
+	"gohub/pkg/logger"
+)
+
+// QueryTokenKey 无法设置请求头时，URL 中传递 Token 的参数名，日志中会被脱敏
+const QueryTokenKey = "token"
+
+// allowQueryTokenKey 请求上下文中的键，标记本次请求允许从 URL 参数读取 Token
+const allowQueryTokenKey = "jwt_allow_query_token"
+
+// AllowQueryToken 允许本次请求从 URL 参数 token 读取 Token，只用于 WebSocket、SSE 等无法设置请求头的路由，
+// 其他路由必须使用 Authorization 请求头，避免 Token 出现在链接、浏览器历史和代理日志中
+func AllowQueryToken(c *gin.Context) {
+	c.Set(allowQueryTokenKey, true)
+}
+
+var (
+	ErrTokenExpired    = errors.New("令牌已过期")
+	ErrTokenMalformed  = errors.New("请求令牌格式有误")
+	ErrTokenInvalid    = errors.New("请求令牌无效")
+	ErrHeaderEmpty     = errors.New("需要认证才能访问！")
+	ErrHeaderMalformed = errors.New("请求头中 Authorization 格式有误")
+)
+
+// JWT 定义一个jwt对象
+type JWT struct {
+	// 秘钥，用以加密 JWT
+	SignKey []byte
+}
+
+// CustomClaims 自定义载荷
+type CustomClaims struct {
+	UserID       string `json:"user_id"`
+	UserName     string `json:"user_name"`
+	ExpireAtTime int64  `json:"expire_time"`
+
+	// StandardClaims 结构体实现了 Claims 接口继承了 Valid() 方法
+	// JWT 规定了7个官方字段，提供使用:
+	// - iss (issuer)：发布者
+	// - sub (subject)：主题
+	// - iat (Issued At)：生成签名的时间
+	// - exp (expiration time)：签名过期时间
+	// - aud (audience)：观众，相当于接受者
+	// - nbf (Not Before)：生效时间
+	// - jti (JWT ID)：编号
+	jwtpkg.StandardClaims
+}
+
+// NewJWT 创建 JWT 对象，未配置 jwt.key 时使用 app.key 签名
+func NewJWT() *JWT {
+	key := config.GetString("jwt.key")
+	if len(key) == 0 {
+		key = config.GetString("app.key")
+	}
+	return &JWT{
+		SignKey: []byte(key),
+	}
+}
+
+// ParseToken 解析请求头中的 Token，解析成功返回载荷
+func (jwt *JWT) ParseToken(c *gin.Context) (*CustomClaims, error) {
+	tokenString, err := jwt.getTokenFromHeader(c)
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := jwtpkg.ParseWithClaims(tokenString, &CustomClaims{}, func(token *jwtpkg.Token) (interface{}, error) {
+		// 只接受签发时使用的 HS256，防止 alg 被篡改
+		if _, ok := token.Method.(*jwtpkg.SigningMethodHMAC); !ok {
+			return nil, ErrTokenInvalid
+		}
+		return jwt.SignKey, nil
+	})
+	if err != nil {
+		var validationErr *jwtpkg.ValidationError
+		if errors.As(err, &validationErr) {
+			if validationErr.Errors&jwtpkg.ValidationErrorMalformed != 0 {
+				return nil, ErrTokenMalformed
+			}
+			if validationErr.Errors&jwtpkg.ValidationErrorExpired != 0 {
+				return nil, ErrTokenExpired
+			}
+		}
+		return nil, ErrTokenInvalid
+	}
+
+	if claims, ok := token.Claims.(*CustomClaims); ok && token.Valid {
+		return claims, nil
+	}
+	return nil, ErrTokenInvalid
+}
+
+// IssueToken 生成 Token，在登录成功时调用
+func (jwt *JWT) IssueToken(userID string, userName string) string {
+	expireAtTime := jwt.expireAtTime()
+	claims := CustomClaims{
+		UserID:       userID,
+		UserName:     userName,
+		ExpireAtTime: expireAtTime,
+		StandardClaims: jwtpkg.StandardClaims{
+			NotBefore: app.TimenowInTimezone().Unix(), // 签名生效时间
+			IssuedAt:  app.TimenowInTimezone().Unix(), // 首次签名时间
+			ExpiresAt: expireAtTime,                   // 签名过期时间
+			Issuer:    config.GetString("app.name"),   // 签名颁发者
+		},
+	}
+
+	token, err := jwtpkg.NewWithClaims(jwtpkg.SigningMethodHS256, claims).SignedString(jwt.SignKey)
+	if err != nil {
+		logger.LogIf(err)
+		return ""
+	}
+	return token
+}
+
+// expireAtTime 过期时间
+func (jwt *JWT) expireAtTime() int64 {
+	timenow := app.TimenowInTimezone()
+
+	var expireTime int64
+	if config.GetBool("app.debug") {
+		expireTime = config.GetInt64("jwt.debug_expire_time")
+	} else {
+		expireTime = config.GetInt64("jwt.expire_time")
+	}
+
+	return timenow.Add(time.Duration(expireTime) * time.Minute).Unix()
+}
+
+// getTokenFromHeader 使用 jwtpkg.ParseWithClaims 解析 Token
+// Authorization:Bearer xxxxx
+// 浏览器的 WebSocket 和 EventSource 无法设置请求头，调用过 AllowQueryToken 的请求
+// 没有 Authorization 时从 URL 参数 token 读取
+func (jwt *JWT) getTokenFromHeader(c *gin.Context) (string, error) {
+	authHeader := c.Request.Header.Get("Authorization")
+	if authHeader == "" {
+		if token := c.Query(QueryTokenKey); token != "" && c.GetBool(allowQueryTokenKey) {
+			return token, nil
+		}
+		return "", ErrHeaderEmpty
+	}
+	// 按空格分割
+	parts := strings.SplitN(authHeader, " ", 2)
+	if !(len(parts) == 2 && parts[0] == "Bearer") {
+		return "", ErrHeaderMalformed
+	}
+	return parts[1], nil
+}
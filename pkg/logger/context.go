@@ -0,0 +1,76 @@
+package logger
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// 上下文相关的日志，自动附带请求 ID，方便关联同一请求产生的日志
+
+// requestIDKey 请求 ID 在 context.Context 中的键
+type requestIDKey struct{}
+
+// WithRequestID 将请求 ID 存入 context
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, requestID)
+}
+
+// RequestIDFromContext 从 context 中获取请求 ID，不存在时返回空字符串
+func RequestIDFromContext(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	requestID, _ := ctx.Value(requestIDKey{}).(string)
+	return requestID
+}
+
+// ContextFields 从 context 中提取需要附带的日志字段，开启追踪时附带 trace_id 和 span_id
+func ContextFields(ctx context.Context) []zap.Field {
+	var fields []zap.Field
+	if requestID := RequestIDFromContext(ctx); len(requestID) > 0 {
+		fields = append(fields, zap.String("request_id", requestID))
+	}
+	if ctx != nil {
+		if sc := trace.SpanContextFromContext(ctx); sc.IsValid() {
+			fields = append(fields,
+				zap.String("trace_id", sc.TraceID().String()),
+				zap.String("span_id", sc.SpanID().String()),
+			)
+		}
+	}
+	return fields
+}
+
+// DebugContext 附带 context 信息的 Debug 日志
+func DebugContext(ctx context.Context, moduleName string, fields ...zap.Field) {
+	Logger.Debug(moduleName, append(fields, ContextFields(ctx)...)...)
+}
+
+// InfoContext 附带 context 信息的 Info 日志
+func InfoContext(ctx context.Context, moduleName string, fields ...zap.Field) {
+	Logger.Info(moduleName, append(fields, ContextFields(ctx)...)...)
+}
+
+// WarnContext 附带 context 信息的 Warn 日志
+func WarnContext(ctx context.Context, moduleName string, fields ...zap.Field) {
+	Logger.Warn(moduleName, append(fields, ContextFields(ctx)...)...)
+}
+
+// ErrorContext 附带 context 信息的 Error 日志
+func ErrorContext(ctx context.Context, moduleName string, fields ...zap.Field) {
+	Logger.Error(moduleName, append(fields, ContextFields(ctx)...)...)
+}
+
+// ErrorStringContext 附带 context 信息，记录一条字符串类型的 Error 日志
+func ErrorStringContext(ctx context.Context, moduleName, name, msg string) {
+	Logger.Error(moduleName, append([]zap.Field{zap.String(name, msg)}, ContextFields(ctx)...)...)
+}
+
+// LogIfContext 当 err != nil 时记录附带 context 信息的 error 等级日志
+func LogIfContext(ctx context.Context, err error) {
+	if err != nil {
+		Logger.Error("Error Occurred:", append([]zap.Field{zap.Error(err)}, ContextFields(ctx)...)...)
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/pkg/errors"
 	"go.uber.org/zap"
 	"gohub/pkg/helpers"
+	"gohub/pkg/metrics"
 	"gorm.io/gorm"
 	gormlogger "gorm.io/gorm/logger"
 )
@@ -63,6 +64,11 @@ func (l GormLogger) Trace(ctx context.Context, begin time.Time, fc func() (strin
 		zap.String("time", helpers.MicrosecondStr(elapsed)),
 		zap.Int64("rows", rows),
 	}
+	// 记录查询耗时指标，记录未找到不算错误
+	metrics.ObserveQuery(sql, elapsed, err != nil && !errors.Is(err, gorm.ErrRecordNotFound))
+
+	// 使用 DB.WithContext 传入请求的 context 时，附带请求 ID
+	logFields = append(logFields, ContextFields(ctx)...)
 
 	// Gorm错误
 	if err != nil {
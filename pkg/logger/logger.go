@@ -111,6 +111,14 @@ func Dump(value interface{}, msg ...string) {
 	}
 }
 
+// Sync 将缓冲区中的日志写入磁盘，程序退出前调用
+func Sync() error {
+	if Logger == nil {
+		return nil
+	}
+	return Logger.Sync()
+}
+
 // LogIf当err!=nil时记录error等级日志
 func LogIf(err error) {
 	if err != nil {
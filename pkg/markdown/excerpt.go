@@ -0,0 +1,42 @@
+package markdown
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/net/html"
+)
+
+// inlineTags 行内元素，提取文本时不需要补空格
+var inlineTags = map[string]bool{
+	"a": true, "abbr": true, "b": true, "code": true, "del": true, "em": true,
+	"i": true, "s": true, "strong": true, "sub": true, "sup": true,
+}
+
+// Excerpt 从渲染后的 HTML 中提取纯文本摘要，用于列表页
+// 合并连续空白，超过 length 个字时截断并添加省略号
+func Excerpt(renderedHTML string, length int) string {
+	var b strings.Builder
+	tokenizer := html.NewTokenizer(strings.NewReader(renderedHTML))
+	for {
+		tt := tokenizer.Next()
+		if tt == html.ErrorToken {
+			break
+		}
+		switch tt {
+		case html.TextToken:
+			b.Write(tokenizer.Text())
+		case html.StartTagToken, html.EndTagToken, html.SelfClosingTagToken:
+			// 块级元素之间补一个空格，避免段落文字粘连
+			if name, _ := tokenizer.TagName(); !inlineTags[string(name)] {
+				b.WriteByte(' ')
+			}
+		}
+	}
+
+	text := strings.Join(strings.Fields(b.String()), " ")
+	if length <= 0 || utf8.RuneCountInString(text) <= length {
+		return text
+	}
+	return string([]rune(text)[:length]) + "..."
+}
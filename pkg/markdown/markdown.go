@@ -0,0 +1,44 @@
+// Package markdown 将用户提交的 Markdown 渲染为安全的 HTML
+package markdown
+
+import (
+	"bytes"
+	"sync"
+
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/extension"
+	"github.com/yuin/goldmark/renderer/html"
+	"gohub/pkg/logger"
+)
+
+// once 确保 internalMarkdown 对象只初始化一次
+var once sync.Once
+
+// internalMarkdown 内部使用的 goldmark 对象
+var internalMarkdown goldmark.Markdown
+
+func md() goldmark.Markdown {
+	once.Do(func() {
+		internalMarkdown = goldmark.New(
+			// GitHub 风格：表格、删除线、自动链接、任务列表
+			goldmark.WithExtensions(extension.GFM),
+			goldmark.WithRendererOptions(
+				// 换行即 <br>，符合中文用户的书写习惯
+				html.WithHardWraps(),
+				// 允许原始 HTML 通过，统一交给 Sanitize 按白名单过滤
+				html.WithUnsafe(),
+			),
+		)
+	})
+	return internalMarkdown
+}
+
+// Render 将 Markdown 渲染为 HTML，并按白名单过滤
+func Render(source string) string {
+	var buf bytes.Buffer
+	if err := md().Convert([]byte(source), &buf); err != nil {
+		logger.LogIf(err)
+		return ""
+	}
+	return Sanitize(buf.String())
+}
@@ -0,0 +1,185 @@
+package markdown
+
+import (
+	"net/url"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// allowedTags 允许的标签及每个标签允许的属性
+var allowedTags = map[string][]string{
+	"a":          {"href", "title"},
+	"abbr":       {"title"},
+	"b":          {},
+	"blockquote": {},
+	"br":         {},
+	"code":       {"class"},
+	"del":        {},
+	"em":         {},
+	"h1":         {},
+	"h2":         {},
+	"h3":         {},
+	"h4":         {},
+	"h5":         {},
+	"h6":         {},
+	"hr":         {},
+	"i":          {},
+	"img":        {"src", "alt", "title"},
+	"input":      {"type", "checked", "disabled"},
+	"li":         {},
+	"ol":         {"start"},
+	"p":          {},
+	"pre":        {},
+	"s":          {},
+	"strong":     {},
+	"sub":        {},
+	"sup":        {},
+	"table":      {},
+	"tbody":      {},
+	"td":         {"align"},
+	"th":         {"align"},
+	"thead":      {},
+	"tr":         {},
+	"ul":         {},
+}
+
+// droppedTags 连同内容一起丢弃的标签
+var droppedTags = map[string]bool{
+	"script":   true,
+	"style":    true,
+	"iframe":   true,
+	"object":   true,
+	"embed":    true,
+	"noscript": true,
+	"template": true,
+	"textarea": true,
+	"select":   true,
+}
+
+// urlAttrs 值为 URL 的属性
+var urlAttrs = map[string]bool{
+	"href": true,
+	"src":  true,
+}
+
+// allowedSchemes 允许的 URL 协议，相对地址不受限制
+var allowedSchemes = map[string]bool{
+	"http":   true,
+	"https":  true,
+	"mailto": true,
+}
+
+// Sanitize 按白名单过滤 HTML：不在白名单的标签只保留文本，
+// 移除 on* 等未允许的属性以及 javascript: 等危险协议的链接
+func Sanitize(input string) string {
+	var b strings.Builder
+	tokenizer := html.NewTokenizer(strings.NewReader(input))
+	// 正在丢弃的标签及嵌套层数
+	dropping, depth := "", 0
+
+	for {
+		tt := tokenizer.Next()
+		if tt == html.ErrorToken {
+			return b.String()
+		}
+		token := tokenizer.Token()
+
+		if dropping != "" {
+			switch {
+			case tt == html.StartTagToken && token.Data == dropping:
+				depth++
+			case tt == html.EndTagToken && token.Data == dropping:
+				depth--
+				if depth == 0 {
+					dropping = ""
+				}
+			}
+			continue
+		}
+
+		switch tt {
+		case html.TextToken:
+			b.WriteString(html.EscapeString(token.Data))
+		case html.StartTagToken, html.SelfClosingTagToken:
+			if droppedTags[token.Data] {
+				if tt == html.StartTagToken {
+					dropping, depth = token.Data, 1
+				}
+				continue
+			}
+			attrs, ok := allowedTags[token.Data]
+			if !ok {
+				continue
+			}
+			if token.Data == "input" && !isCheckbox(token) {
+				continue
+			}
+			token.Attr = filterAttrs(token.Attr, attrs)
+			b.WriteString(token.String())
+		case html.EndTagToken:
+			if _, ok := allowedTags[token.Data]; ok {
+				b.WriteString(token.String())
+			}
+		}
+	}
+}
+
+// filterAttrs 只保留允许的属性，URL 属性需通过协议检查
+func filterAttrs(attrs []html.Attribute, allowed []string) []html.Attribute {
+	filtered := attrs[:0]
+	for _, attr := range attrs {
+		name := strings.ToLower(attr.Key)
+		if attr.Namespace != "" || !contains(allowed, name) {
+			continue
+		}
+		if urlAttrs[name] && !isSafeURL(attr.Val) {
+			continue
+		}
+		attr.Key = name
+		filtered = append(filtered, attr)
+	}
+	return filtered
+}
+
+// isSafeURL 判断 URL 是否为相对地址或允许的协议
+func isSafeURL(raw string) bool {
+	// 去除浏览器会忽略的空白和控制字符，防止 "java\tscript:" 绕过
+	cleaned := strings.Map(func(r rune) rune {
+		if r <= ' ' || r == 0x7f {
+			return -1
+		}
+		return r
+	}, raw)
+	u, err := url.Parse(cleaned)
+	if err != nil {
+		return false
+	}
+	if u.Scheme == "" {
+		// 没有协议，但冒号出现在第一个 / 之前，可能是未能解析的协议
+		if i := strings.Index(cleaned, ":"); i >= 0 && !strings.Contains(cleaned[:i], "/") {
+			return false
+		}
+		return true
+	}
+	return allowedSchemes[strings.ToLower(u.Scheme)]
+}
+
+// isCheckbox 只允许 GFM 任务列表生成的复选框
+func isCheckbox(token html.Token) bool {
+	for _, attr := range token.Attr {
+		if strings.ToLower(attr.Key) == "type" {
+			return strings.ToLower(attr.Val) == "checkbox"
+		}
+	}
+	return false
+}
+
+func contains(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
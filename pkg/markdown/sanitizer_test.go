@@ -0,0 +1,67 @@
+package markdown
+
+import "testing"
+
+func TestSanitize(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"javascript 协议中插入制表符", "<a href=\"java\tscript:alert(1)\">x</a>", "<a>x</a>"},
+		{"制表符使用实体编码", `<a href="jav&#x09;ascript:alert(1)">x</a>`, "<a>x</a>"},
+		{"javascript 协议大小写混合", `<a href="JaVaScRiPt:alert(1)">x</a>`, "<a>x</a>"},
+		{"javascript 协议前有空格", `<a href=" javascript:alert(1)">x</a>`, "<a>x</a>"},
+		{"允许的链接", `<a href="https://example.com" title="t">x</a>`, `<a href="https://example.com" title="t">x</a>`},
+		{"svg onload", `<svg onload="alert(1)"><circle/></svg>`, ""},
+		{"svg onload 无空格", `<svg/onload=alert(1)>`, ""},
+		{"img onerror", `<img src="x.png" onerror="alert(1)">`, `<img src="x.png">`},
+		{"p onclick", `<p onclick="alert(1)">hi</p>`, "<p>hi</p>"},
+		{"嵌套 script", "<script><script>alert(1)</script>x</script>y", "xy"},
+		{"script 嵌在允许的标签内", "<p><script>alert(1)</script>ok</p>", "<p>ok</p>"},
+		{"拆开的 script 标签", "<scr<script>ipt>alert(1)</script>", "ipt&gt;alert(1)"},
+		{"嵌套 object", "<object><object></object>x</object>y", "y"},
+		{"文本输入框", `<input type="text" value="x">`, ""},
+		{"隐藏输入框", `<input type="hidden" name="csrf">`, ""},
+		{"没有 type 的输入框", "<input>", ""},
+		{"任务列表复选框", `<input type="checkbox" checked disabled>`, `<input type="checkbox" checked="" disabled="">`},
+		{"复选框的事件属性", `<input onclick="alert(1)" type="CHECKBOX">`, `<input type="CHECKBOX">`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Sanitize(tt.input); got != tt.want {
+				t.Errorf("Sanitize(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsSafeURL(t *testing.T) {
+	tests := []struct {
+		raw  string
+		want bool
+	}{
+		{"https://example.com/a?b=c", true},
+		{"http://example.com", true},
+		{"mailto:user@example.com", true},
+		{"/topics/1", true},
+		{"topics/1#reply", true},
+		{"#anchor", true},
+		{"javascript:alert(1)", false},
+		{"JAVASCRIPT:alert(1)", false},
+		{"java\tscript:alert(1)", false},
+		{"java\nscript:alert(1)", false},
+		{"java\x00script:alert(1)", false},
+		{" javascript:alert(1)", false},
+		{"vbscript:msgbox(1)", false},
+		{"data:text/html;base64,PHNjcmlwdD4=", false},
+		{"javascript%3Aalert(1):x", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.raw, func(t *testing.T) {
+			if got := isSafeURL(tt.raw); got != tt.want {
+				t.Errorf("isSafeURL(%q) = %v, want %v", tt.raw, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,111 @@
+// Package metrics 应用指标，使用 Prometheus 文本格式暴露
+package metrics
+
+import (
+	"database/sql"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Registry 应用使用的指标注册表，不使用 prometheus 的全局注册表，避免第三方库注入指标
+var Registry = prometheus.NewRegistry()
+
+var (
+	httpRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "http_requests_total",
+		Help: "HTTP 请求总数",
+	}, []string{"method", "route", "status"})
+
+	httpDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "http_request_duration_seconds",
+		Help:    "HTTP 请求耗时",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"method", "route", "status"})
+
+	dbQueryDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "db_query_duration_seconds",
+		Help:    "SQL 查询耗时",
+		Buckets: []float64{.001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5},
+	}, []string{"operation"})
+
+	dbQueryErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "db_query_errors_total",
+		Help: "SQL 查询出错次数，不含记录未找到",
+	}, []string{"operation"})
+
+	redisDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "redis_command_duration_seconds",
+		Help:    "Redis 命令耗时",
+		Buckets: []float64{.0005, .001, .0025, .005, .01, .025, .05, .1, .25, .5},
+	}, []string{"command"})
+
+	redisErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "redis_command_errors_total",
+		Help: "Redis 命令出错次数，不含 key 不存在",
+	}, []string{"command"})
+)
+
+func init() {
+	Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		httpRequests, httpDuration,
+		dbQueryDuration, dbQueryErrors,
+		redisDuration, redisErrors,
+	)
+}
+
+// RegisterDB 注册 database/sql 连接池指标，数据来自 SQLDB.Stats()
+func RegisterDB(db *sql.DB, dbName string) {
+	Registry.MustRegister(collectors.NewDBStatsCollector(db, dbName))
+}
+
+// Handler 输出 Prometheus 文本格式的指标
+func Handler() gin.HandlerFunc {
+	h := promhttp.HandlerFor(Registry, promhttp.HandlerOpts{})
+	return gin.WrapH(h)
+}
+
+// ObserveHTTP 记录一次 HTTP 请求，route 为路由模板，如 /v1/users/:id/followers
+func ObserveHTTP(method, route string, status int, elapsed time.Duration) {
+	code := strconv.Itoa(status)
+	httpRequests.WithLabelValues(method, route, code).Inc()
+	httpDuration.WithLabelValues(method, route, code).Observe(elapsed.Seconds())
+}
+
+// ObserveQuery 记录一次 SQL 查询，按语句类型（select、insert 等）分组
+func ObserveQuery(sql string, elapsed time.Duration, failed bool) {
+	operation := sqlOperation(sql)
+	dbQueryDuration.WithLabelValues(operation).Observe(elapsed.Seconds())
+	if failed {
+		dbQueryErrors.WithLabelValues(operation).Inc()
+	}
+}
+
+// ObserveRedis 记录一次 Redis 命令
+func ObserveRedis(command string, elapsed time.Duration, failed bool) {
+	redisDuration.WithLabelValues(command).Observe(elapsed.Seconds())
+	if failed {
+		redisErrors.WithLabelValues(command).Inc()
+	}
+}
+
+// sqlOperation 取 SQL 的第一个关键字，控制标签的基数
+func sqlOperation(sql string) string {
+	fields := strings.Fields(sql)
+	if len(fields) == 0 {
+		return "unknown"
+	}
+	switch op := strings.ToLower(fields[0]); op {
+	case "select", "insert", "update", "delete", "replace", "with":
+		return op
+	default:
+		return "other"
+	}
+}
@@ -0,0 +1,7 @@
+package notify
+
+// Channel 通知渠道，如数据库、WebSocket 推送
+type Channel interface {
+	// 发送通知
+	Send(n Notification) error
+}
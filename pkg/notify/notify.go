@@ -0,0 +1,68 @@
+// Package notify 站内通知分发
+package notify
+
+import (
+	"sync"
+
+	"gohub/pkg/logger"
+)
+
+// 通知类型
+const (
+	// TypeUserFollowed 被其他用户关注
+	TypeUserFollowed = "user_followed"
+	// TypeTopicReplied 话题被回复
+	TypeTopicReplied = "topic_replied"
+)
+
+// Notification 待分发的通知
+type Notification struct {
+	UserID uint64                 // 接收通知的用户
+	Type   string                 // 通知类型
+	Data   map[string]interface{} // 通知内容
+}
+
+// Dispatcher 通知分发器，将通知发送到所有已注册的 Channel
+type Dispatcher struct {
+	mu       sync.RWMutex
+	channels []Channel
+}
+
+// once 单例模式
+var once sync.Once
+
+// internalDispatcher 内部使用的 Dispatcher 对象
+var internalDispatcher *Dispatcher
+
+// NewDispatcher 单例模式获取
+func NewDispatcher() *Dispatcher {
+	once.Do(func() {
+		internalDispatcher = &Dispatcher{}
+	})
+	return internalDispatcher
+}
+
+// Use 注册通知渠道
+func (d *Dispatcher) Use(channels ...Channel) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.channels = append(d.channels, channels...)
+}
+
+// Dispatch 分发通知，某个渠道失败只记录日志，不影响其他渠道
+func (d *Dispatcher) Dispatch(n Notification) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	for _, ch := range d.channels {
+		logger.LogIf(ch.Send(n))
+	}
+}
+
+// Send 通知 userID 用户，供 models 和 controllers 调用
+func Send(userID uint64, notificationType string, data map[string]interface{}) {
+	NewDispatcher().Dispatch(Notification{
+		UserID: userID,
+		Type:   notificationType,
+		Data:   data,
+	})
+}
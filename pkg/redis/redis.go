@@ -7,6 +7,7 @@ import (
 
 	"github.com/go-redis/redis/v8"
 	"gohub/pkg/logger"
+	"gohub/pkg/tracing"
 )
 
 // redis 工具包
@@ -43,34 +44,62 @@ func NewClient(address string, username string, password string, db int) *RedisC
 		Password: password,
 		DB:       db,
 	})
+	// 记录命令耗时指标
+	rds.Client.AddHook(metricsHook{})
+	// 开启追踪时，为每条命令创建 span
+	if tracing.Enabled() {
+		rds.Client.AddHook(tracingHook{})
+	}
 	// 测试连接
 	err := rds.Ping()
 	logger.LogIf(err)
 	return rds
 }
 
+// WithContext 返回使用 ctx 的 RedisClient 副本，
+// 传入请求的 context 后，命令会随请求取消，日志也会附带请求 ID
+func (rds RedisClient) WithContext(ctx context.Context) *RedisClient {
+	rds.Context = ctx
+	return &rds
+}
+
 // Ping 测试redis连接是否正常
 func (rds RedisClient) Ping() error {
 	_, err := rds.Client.Ping(rds.Context).Result()
 	return err
 }
 
+// Close 关闭 redis 连接池
+func (rds RedisClient) Close() error {
+	return rds.Client.Close()
+}
+
 // Set存储key对应的value,且设置expiration过期时间
 
 func (rds RedisClient) Set(key string, value interface{}, expiration time.Duration) bool {
 	if err := rds.Client.Set(rds.Context, key, value, expiration).Err(); err != nil {
-		logger.ErrorString("Redis", "Set", err.Error())
+		logger.ErrorStringContext(rds.Context, "Redis", "Set", err.Error())
 		return false
 	}
 	return true
 }
 
+// SetNX key 不存在时才存储，成功返回 true，key 已存在或出错返回 false
+func (rds RedisClient) SetNX(key string, value interface{}, expiration time.Duration) bool {
+	ok, err := rds.Client.SetNX(rds.Context, key, value, expiration).Result()
+	if err != nil {
+		logger.ErrorStringContext(rds.Context, "Redis", "SetNX", err.Error())
+		return false
+	}
+	return ok
+}
+
 // Get 获取key对应的value
 func (rds RedisClient) Get(key string) string {
 	result, err := rds.Client.Get(rds.Context, key).Result()
 	if err != nil {
 		if err != redis.Nil {
-			logger.ErrorString("Redis", "Get", err.Error())
+			logger.ErrorStringContext(rds.Context, "Redis", "Get", err.Error())
 		}
 		return ""
 	}
@@ -82,17 +111,27 @@ func (rds RedisClient) Has(key string) bool {
 	_, err := rds.Client.Get(rds.Context, key).Result()
 	if err != nil {
 		if err != redis.Nil {
-			logger.ErrorString("Redis", "Has", err.Error())
+			logger.ErrorStringContext(rds.Context, "Redis", "Has", err.Error())
 		}
 		return false
 	}
 	return true
 }
 
+// Exists 判断 key 是否存在，适用于任意类型的 key，出错时返回 false
+func (rds RedisClient) Exists(key string) bool {
+	n, err := rds.Client.Exists(rds.Context, key).Result()
+	if err != nil {
+		logger.ErrorStringContext(rds.Context, "Redis", "Exists", err.Error())
+		return false
+	}
+	return n > 0
+}
+
 // Del 删除存储在 redis 里的数据，支持多个 key 传参
 func (rds RedisClient) Del(keys ...string) bool {
 	if err := rds.Client.Del(rds.Context, keys...).Err(); err != nil {
-		logger.ErrorString("Redis", "Del", err.Error())
+		logger.ErrorStringContext(rds.Context, "Redis", "Del", err.Error())
 		return false
 	}
 	return true
@@ -101,7 +140,7 @@ func (rds RedisClient) Del(keys ...string) bool {
 // FlushDB 清空当前 redis db 里的所有数据
 func (rds RedisClient) FlushDB() bool {
 	if err := rds.Client.FlushDB(rds.Context).Err(); err != nil {
-		logger.ErrorString("Redis", "FlushDB", err.Error())
+		logger.ErrorStringContext(rds.Context, "Redis", "FlushDB", err.Error())
 		return false
 	}
 	return true
@@ -113,18 +152,18 @@ func (rds RedisClient) Increment(parameters ...interface{}) bool {
 	case 1:
 		key := parameters[0].(string)
 		if err := rds.Client.Incr(rds.Context, key).Err(); err != nil {
-			logger.ErrorString("Redis", "Increment", err.Error())
+			logger.ErrorStringContext(rds.Context, "Redis", "Increment", err.Error())
 			return false
 		}
 	case 2:
 		key := parameters[0].(string)
 		value := parameters[1].(int64)
 		if err := rds.Client.IncrBy(rds.Context, key, value).Err(); err != nil {
-			logger.ErrorString("Redis", "Increment", err.Error())
+			logger.ErrorStringContext(rds.Context, "Redis", "Increment", err.Error())
 			return false
 		}
 	default:
-		logger.ErrorString("Redis", "Increment", "参数过多")
+		logger.ErrorStringContext(rds.Context, "Redis", "Increment", "参数过多")
 		return false
 	}
 	return true
@@ -136,19 +175,168 @@ func (rds RedisClient) Decrement(parameters ...interface{}) bool {
 	case 1:
 		key := parameters[0].(string)
 		if err := rds.Client.Decr(rds.Context, key).Err(); err != nil {
-			logger.ErrorString("Redis", "Decrement", err.Error())
+			logger.ErrorStringContext(rds.Context, "Redis", "Decrement", err.Error())
 			return false
 		}
 	case 2:
 		key := parameters[0].(string)
 		value := parameters[1].(int64)
 		if err := rds.Client.DecrBy(rds.Context, key, value).Err(); err != nil {
-			logger.ErrorString("Redis", "Decrement", err.Error())
+			logger.ErrorStringContext(rds.Context, "Redis", "Decrement", err.Error())
 			return false
 		}
 	default:
-		logger.ErrorString("Redis", "Decrement", "参数过多")
+		logger.ErrorStringContext(rds.Context, "Redis", "Decrement", "参数过多")
+		return false
+	}
+	return true
+}
+
+// Publish 发布消息到 channel
+func (rds RedisClient) Publish(channel string, message interface{}) bool {
+	if err := rds.Client.Publish(rds.Context, channel, message).Err(); err != nil {
+		logger.ErrorStringContext(rds.Context, "Redis", "Publish", err.Error())
 		return false
 	}
 	return true
 }
+
+// Subscribe 订阅 channel，调用方负责 Close
+func (rds RedisClient) Subscribe(channels ...string) *redis.PubSub {
+	return rds.Client.Subscribe(rds.Context, channels...)
+}
+
+// XAdd 追加消息到 stream，maxLen 大于 0 时近似截断为 maxLen 条，返回消息 ID
+func (rds RedisClient) XAdd(stream string, maxLen int64, values map[string]interface{}) string {
+	id, err := rds.Client.XAdd(rds.Context, &redis.XAddArgs{
+		Stream: stream,
+		MaxLen: maxLen,
+		Approx: true,
+		Values: values,
+	}).Result()
+	if err != nil {
+		logger.ErrorStringContext(rds.Context, "Redis", "XAdd", err.Error())
+		return ""
+	}
+	return id
+}
+
+// XRead 从 stream 的 lastID 之后读取消息，block 为 0 时不阻塞
+// 阻塞超时没有消息时返回空切片和 nil，出错时返回错误，由调用方决定重试或放弃
+func (rds RedisClient) XRead(ctx context.Context, stream string, lastID string, count int64, block time.Duration) ([]redis.XMessage, error) {
+	if block <= 0 {
+		block = -1
+	}
+	result, err := rds.Client.XRead(ctx, &redis.XReadArgs{
+		Streams: []string{stream, lastID},
+		Count:   count,
+		Block:   block,
+	}).Result()
+	if err != nil {
+		if err == redis.Nil {
+			return nil, nil
+		}
+		if ctx.Err() == nil {
+			logger.ErrorStringContext(ctx, "Redis", "XRead", err.Error())
+		}
+		return nil, err
+	}
+	if len(result) == 0 {
+		return nil, nil
+	}
+	return result[0].Messages, nil
+}
+
+// XLastID 获取 stream 最后一条消息的 ID，stream 为空时返回 0-0
+func (rds RedisClient) XLastID(stream string) string {
+	messages, err := rds.Client.XRevRangeN(rds.Context, stream, "+", "-", 1).Result()
+	if err != nil {
+		logger.ErrorStringContext(rds.Context, "Redis", "XLastID", err.Error())
+	}
+	if len(messages) == 0 {
+		return "0-0"
+	}
+	return messages[0].ID
+}
+
+// ZAdd 添加成员到有序集合
+func (rds RedisClient) ZAdd(key string, score float64, members ...string) bool {
+	zs := make([]*redis.Z, len(members))
+	for i, member := range members {
+		zs[i] = &redis.Z{Score: score, Member: member}
+	}
+	if err := rds.Client.ZAdd(rds.Context, key, zs...).Err(); err != nil {
+		logger.ErrorStringContext(rds.Context, "Redis", "ZAdd", err.Error())
+		return false
+	}
+	return true
+}
+
+// ZAddScores 添加多个成员到有序集合，scores 为 成员 => 分值
+func (rds RedisClient) ZAddScores(key string, scores map[string]float64) bool {
+	zs := make([]*redis.Z, 0, len(scores))
+	for member, score := range scores {
+		zs = append(zs, &redis.Z{Score: score, Member: member})
+	}
+	if err := rds.Client.ZAdd(rds.Context, key, zs...).Err(); err != nil {
+		logger.ErrorStringContext(rds.Context, "Redis", "ZAddScores", err.Error())
+		return false
+	}
+	return true
+}
+
+// ZRem 从有序集合中删除成员
+func (rds RedisClient) ZRem(key string, members ...string) bool {
+	values := make([]interface{}, len(members))
+	for i, member := range members {
+		values[i] = member
+	}
+	if err := rds.Client.ZRem(rds.Context, key, values...).Err(); err != nil {
+		logger.ErrorStringContext(rds.Context, "Redis", "ZRem", err.Error())
+		return false
+	}
+	return true
+}
+
+// ZRevRange 按分值从高到低获取有序集合中 start 到 stop 的成员
+func (rds RedisClient) ZRevRange(key string, start, stop int64) []string {
+	members, err := rds.Client.ZRevRange(rds.Context, key, start, stop).Result()
+	if err != nil {
+		logger.ErrorStringContext(rds.Context, "Redis", "ZRevRange", err.Error())
+		return nil
+	}
+	return members
+}
+
+// ZCard 有序集合的成员数
+func (rds RedisClient) ZCard(key string) int64 {
+	count, err := rds.Client.ZCard(rds.Context, key).Result()
+	if err != nil {
+		logger.ErrorStringContext(rds.Context, "Redis", "ZCard", err.Error())
+		return 0
+	}
+	return count
+}
+
+// ZRemRangeByRank 按排名删除有序集合中的成员，排名从分值最低的 0 开始，支持负数
+func (rds RedisClient) ZRemRangeByRank(key string, start, stop int64) bool {
+	if err := rds.Client.ZRemRangeByRank(rds.Context, key, start, stop).Err(); err != nil {
+		logger.ErrorStringContext(rds.Context, "Redis", "ZRemRangeByRank", err.Error())
+		return false
+	}
+	return true
+}
+
+// ZRangeByLex 按字典序获取有序集合中以 prefix 开头的成员，成员分值需相同
+func (rds RedisClient) ZRangeByLex(key string, prefix string, limit int64) []string {
+	members, err := rds.Client.ZRangeByLex(rds.Context, key, &redis.ZRangeBy{
+		Min:   "[" + prefix,
+		Max:   "[" + prefix + "\xff",
+		Count: limit,
+	}).Result()
+	if err != nil {
+		logger.ErrorStringContext(rds.Context, "Redis", "ZRangeByLex", err.Error())
+		return nil
+	}
+	return members
+}
@@ -0,0 +1,84 @@
+// Package respcache 接口响应缓存，按标签失效
+package respcache
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"gohub/pkg/config"
+	"gohub/pkg/redis"
+)
+
+// 每个标签在 Redis 中有一个版本号，缓存键包含所属标签的版本号，
+// 失效时只需将版本号加 1，旧的缓存不再被命中，随 TTL 自然过期
+
+// Entry 缓存的响应
+type Entry struct {
+	Status      int    `json:"status"`
+	ContentType string `json:"content_type"`
+	Body        []byte `json:"body"`
+}
+
+// Key 根据请求特征和标签的当前版本生成缓存键
+func Key(ctx context.Context, parts []string, tags []string) string {
+	rds := redis.Redis.WithContext(ctx)
+	versions := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		version := rds.Get(tagKey(tag))
+		if version == "" {
+			version = "0"
+		}
+		versions = append(versions, tag+"="+version)
+	}
+
+	h := sha256.New()
+	h.Write([]byte(strings.Join(parts, "\n")))
+	h.Write([]byte{0})
+	h.Write([]byte(strings.Join(versions, ",")))
+	return prefix() + hex.EncodeToString(h.Sum(nil))
+}
+
+// Get 读取缓存的响应
+func Get(ctx context.Context, key string) (Entry, bool) {
+	var entry Entry
+	value := redis.Redis.WithContext(ctx).Get(key)
+	if value == "" {
+		return entry, false
+	}
+	if err := json.Unmarshal([]byte(value), &entry); err != nil {
+		return entry, false
+	}
+	return entry, true
+}
+
+// Set 缓存响应
+func Set(ctx context.Context, key string, entry Entry, ttl time.Duration) {
+	value, err := json.Marshal(entry)
+	if err != nil {
+		return
+	}
+	redis.Redis.WithContext(ctx).Set(key, value, ttl)
+}
+
+// Invalidate 使带有这些标签的缓存全部失效，在数据变更的事务提交后调用。
+// 不要在 GORM 模型钩子中调用：钩子在事务内执行，提交前的并发请求可能读到旧数据并重新写入缓存
+func Invalidate(tags ...string) {
+	if redis.Redis == nil {
+		return
+	}
+	for _, tag := range tags {
+		redis.Redis.Increment(tagKey(tag))
+	}
+}
+
+func prefix() string {
+	return config.GetString("app.name") + ":respcache:"
+}
+
+func tagKey(tag string) string {
+	return prefix() + "tag:" + tag
+}
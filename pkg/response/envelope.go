@@ -0,0 +1,105 @@
+package response
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gohub/pkg/config"
+	"gohub/pkg/errcode"
+	"gohub/pkg/helpers"
+	"gohub/pkg/logger"
+)
+
+// StartTimeKey 请求开始时间在 gin.Context 中的键名，由 middlewares.Logger 设置
+const StartTimeKey = "request_start_time"
+
+// RequestIDKey 请求 ID 在 gin.Context 中的键名
+const RequestIDKey = "request_id"
+
+// useEnvelope 是否使用统一的成功响应格式
+func useEnvelope() bool {
+	return config.GetBool("response.envelope")
+}
+
+// stableBodyKey 响应中不含 request_id 和 meta.took 的部分在 gin.Context 中的键名
+const stableBodyKey = "response_stable_body"
+
+// writeEnvelope 输出统一的成功响应格式 {success, data, meta, request_id}。
+// 每次请求都不同的 request_id 和 meta.took 不参与序列化，而是拼接在稳定部分之后，
+// 稳定部分保存在 gin.Context 中，供 ETag 和接口缓存使用（见 StableBody）
+func writeEnvelope(c *gin.Context, status int, data interface{}, meta gin.H) {
+	if meta == nil {
+		meta = gin.H{}
+	}
+	meta["code"] = errcode.OK.Code
+	meta["key"] = errcode.OK.Key
+
+	stable, err := json.Marshal(gin.H{
+		"success": true,
+		"data":    data,
+		"meta":    meta,
+	})
+	if err != nil {
+		logger.LogIf(err)
+		c.AbortWithStatus(http.StatusInternalServerError)
+		return
+	}
+	c.Data(status, "application/json; charset=utf-8", StampBody(c, stable))
+}
+
+// requestID 当前请求的 ID
+func requestID(c *gin.Context) string {
+	if id := c.GetString(RequestIDKey); len(id) > 0 {
+		return id
+	}
+	return c.GetHeader("X-Request-ID")
+}
+
+// StableBody 返回本次响应中不含 request_id 和 meta.took 的部分，用于计算 ETag 和缓存响应。
+// 未通过统一响应格式输出时原样返回 body
+func StableBody(c *gin.Context, body []byte) []byte {
+	if stable, ok := c.Get(stableBodyKey); ok {
+		if stable, ok := stable.([]byte); ok {
+			return stable
+		}
+	}
+	return body
+}
+
+// StampBody 在稳定部分后拼接当前请求的 meta.took 和 request_id，得到完整的响应，StableBody 的逆操作。
+// 稳定部分原样保留，不重新编码，未开启 envelope 时原样返回
+func StampBody(c *gin.Context, stable []byte) []byte {
+	// 稳定部分为 json.Marshal 的 {"data":...,"meta":{...},"success":true}，meta 至少含 code 和 key
+	suffix := []byte(`},"success":true}`)
+	if !useEnvelope() || !bytes.HasSuffix(stable, suffix) {
+		return stable
+	}
+	c.Set(stableBodyKey, stable)
+
+	var volatile bytes.Buffer
+	if start, ok := c.Get(StartTimeKey); ok {
+		if startTime, ok := start.(time.Time); ok {
+			volatile.WriteString(`,"took":`)
+			volatile.Write(jsonString(helpers.MicrosecondStr(time.Since(startTime))))
+		}
+	}
+	volatile.WriteString(`},"request_id":`)
+	volatile.Write(jsonString(requestID(c)))
+	volatile.WriteString(`,"success":true}`)
+
+	body := make([]byte, 0, len(stable)+volatile.Len())
+	body = append(body, stable[:len(stable)-len(suffix)]...)
+	return append(body, volatile.Bytes()...)
+}
+
+// jsonString 编码 JSON 字符串，编码失败时返回空字符串
+func jsonString(s string) []byte {
+	b, err := json.Marshal(s)
+	if err != nil {
+		return []byte(`""`)
+	}
+	return b
+}
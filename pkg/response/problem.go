@@ -0,0 +1,41 @@
+package response
+
+import (
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"gohub/pkg/app"
+	"gohub/pkg/config"
+	"gohub/pkg/errcode"
+	"gohub/pkg/i18n"
+)
+
+// problemContentType RFC 7807 problem details 的 Content-Type
+const problemContentType = "application/problem+json"
+
+// wantsProblem 是否使用 problem details 响应错误：需配置开启，且客户端 Accept 该格式
+func wantsProblem(c *gin.Context) bool {
+	return config.GetBool("response.problem_details") &&
+		strings.Contains(c.GetHeader("Accept"), problemContentType)
+}
+
+// problem 生成 problem details，code 和 key 作为扩展字段保留
+// type 指向错误码列表中对应的条目，title 为错误码的默认消息，detail 为本次的具体消息
+func problem(c *gin.Context, status int, code errcode.Code, detail string) gin.H {
+	return gin.H{
+		"type":     app.V1URL("error-codes") + "#" + code.Key,
+		"title":    i18n.Trans(c, code.MessageKey),
+		"status":   status,
+		"detail":   detail,
+		"instance": c.Request.URL.RequestURI(),
+		"code":     code.Code,
+		"key":      code.Key,
+	}
+}
+
+// abortWithProblem 以 application/problem+json 响应
+func abortWithProblem(c *gin.Context, status int, body gin.H) {
+	// 需在写入响应前设置，gin 不会覆盖已设置的 Content-Type
+	c.Header("Content-Type", problemContentType)
+	c.AbortWithStatusJSON(status, body)
+}
@@ -4,15 +4,26 @@ import (
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	"gohub/pkg/errcode"
+	"gohub/pkg/i18n"
 	"gohub/pkg/logger"
+	"gohub/pkg/paginator"
 	"gorm.io/gorm"
 )
 
 // 响应处理工具
+// 除 JSON 和 CreatedJSON 原样输出外，其他响应都带有 code（数字错误码）和 key（错误标识），
+// 错误码定义在 pkg/errcode
+// 开启 response.envelope 后，所有成功响应统一为 {success, data, meta, request_id}（见 envelope.go）
+// 开启 response.problem_details 后，错误响应可按客户端 Accept 使用 RFC 7807 格式（见 problem.go）
 
 // JSON响应200和JSON数据
 
 func JSON(c *gin.Context, data interface{}) {
+	if useEnvelope() {
+		writeEnvelope(c, http.StatusOK, data, nil)
+		return
+	}
 	c.JSON(http.StatusOK, data)
 }
 
@@ -20,9 +31,17 @@ func JSON(c *gin.Context, data interface{}) {
 // 执行某个 没有具体返回数据的变更操作成功后调用，如删除、修改秘密、修改手机号
 
 func Success(c *gin.Context) {
+	if useEnvelope() {
+		writeEnvelope(c, http.StatusOK, nil, gin.H{
+			"message": i18n.Trans(c, "response.success"),
+		})
+		return
+	}
 	JSON(c, gin.H{
 		"success": true,
-		"message": "操作成功！",
+		"code":    errcode.OK.Code,
+		"key":     errcode.OK.Key,
+		"message": i18n.Trans(c, "response.success"),
 	})
 }
 
@@ -30,8 +49,14 @@ func Success(c *gin.Context) {
 // 执行更新成功后调用，比如更新话题，成功后返回已更新的话题
 
 func Data(c *gin.Context, data interface{}) {
+	if useEnvelope() {
+		writeEnvelope(c, http.StatusOK, data, nil)
+		return
+	}
 	JSON(c, gin.H{
 		"success": true,
+		"code":    errcode.OK.Code,
+		"key":     errcode.OK.Key,
 		"data":    data,
 	})
 }
@@ -40,39 +65,63 @@ func Data(c *gin.Context, data interface{}) {
 // 执行更新成功后调用，比如更新话题，成功后返回已更新的话题
 
 func Created(c *gin.Context, data interface{}) {
+	if useEnvelope() {
+		writeEnvelope(c, http.StatusCreated, data, nil)
+		return
+	}
 	c.JSON(http.StatusCreated, gin.H{
 		"success": true,
+		"code":    errcode.OK.Code,
+		"key":     errcode.OK.Key,
 		"data":    data,
 	})
 }
 
 // CreatedJSON 响应201和JSON数据
 func CreatedJSON(c *gin.Context, data interface{}) {
+	if useEnvelope() {
+		writeEnvelope(c, http.StatusCreated, data, nil)
+		return
+	}
 	c.JSON(http.StatusCreated, data)
 }
 
+// Paginated 响应200和分页数据，统一格式下分页信息放在 meta.pagination 中
+func Paginated(c *gin.Context, data interface{}, pager paginator.Paging) {
+	if useEnvelope() {
+		writeEnvelope(c, http.StatusOK, data, gin.H{"pagination": pager})
+		return
+	}
+	JSON(c, gin.H{
+		"success": true,
+		"code":    errcode.OK.Code,
+		"key":     errcode.OK.Key,
+		"data":    data,
+		"pager":   pager,
+	})
+}
+
+// Abort 使用错误码对应的 HTTP 状态码响应，未传参msg使用错误码的默认消息
+func Abort(c *gin.Context, code errcode.Code, msg ...string) {
+	abortError(c, code.Status, code, nil, msg...)
+}
+
 // Abort404响应404，未传参msg使用默认消息
 
 func Abort404(c *gin.Context, msg ...string) {
-	c.AbortWithStatusJSON(http.StatusNotFound, gin.H{
-		"message": defaultMessage("数据不存在，请确认请求正确", msg...),
-	})
+	abortError(c, http.StatusNotFound, errcode.NotFound, nil, msg...)
 }
 
 // Abort403响应403，未传参msg使用默认消息
 
 func Abort403(c *gin.Context, msg ...string) {
-	c.AbortWithStatusJSON(http.StatusForbidden, gin.H{
-		"message": defaultMessage("权限不足，请确认是否有对应权限", msg...),
-	})
+	abortError(c, http.StatusForbidden, errcode.Forbidden, nil, msg...)
 }
 
 // Abort500响应500，未传参msg使用默认消息
 
 func Abort500(c *gin.Context, msg ...string) {
-	c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{
-		"message": defaultMessage("服务内部错误，请稍后再试", msg...),
-	})
+	abortError(c, http.StatusInternalServerError, errcode.InternalError, nil, msg...)
 }
 
 // BadRequest 响应400，传参err对象，未传参msg使用默认消息
@@ -81,10 +130,7 @@ func Abort500(c *gin.Context, msg ...string) {
 func BadRequest(c *gin.Context, err error, msg ...string) {
 	logger.LogIf(err)
 
-	c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
-		"message": defaultMessage("请求解析错误，请确认请求格式是否正确。上传文件使用multipart头，参数使用json格式", msg...),
-		"error":   err.Error(),
-	})
+	abortError(c, http.StatusBadRequest, errcode.BadRequest, gin.H{"error": err.Error()}, msg...)
 }
 
 // Error 响应404或422，未传参msg使用默认消息
@@ -96,34 +142,96 @@ func Error(c *gin.Context, err error, msg ...string) {
 		Abort404(c)
 		return
 	}
-	c.AbortWithStatusJSON(http.StatusUnprocessableEntity, gin.H{
-		"message": defaultMessage("请求处理失败，请查看error信息", msg...),
-		"error":   err.Error(),
-	})
+
+	abortError(c, http.StatusUnprocessableEntity, errcode.RequestFailed, gin.H{"error": err.Error()}, msg...)
 }
 
 // ValidationError 处理表单验证不通过的错误，返回JSON
+// errors 中的消息为语言包消息键时会被翻译，并在 error_codes 中返回对应的错误码
 
 func ValidationError(c *gin.Context, errors map[string][]string) {
-	c.AbortWithStatusJSON(http.StatusUnprocessableEntity, gin.H{
-		"message": "请求验证不通过，具体查看errors",
-		"errors":  errors,
+	messages := make(map[string][]string, len(errors))
+	codes := make(map[string][]int, len(errors))
+	// problem details 中的 invalid-params
+	invalidParams := make([]gin.H, 0, len(errors))
+	for field, fieldErrors := range errors {
+		for _, message := range fieldErrors {
+			code, ok := errcode.ByMessageKey(i18n.Key(message))
+			if !ok {
+				code = errcode.ValidationFailed
+			}
+			reason := i18n.Trans(c, message)
+			messages[field] = append(messages[field], reason)
+			codes[field] = append(codes[field], code.Code)
+			invalidParams = append(invalidParams, gin.H{
+				"name":   field,
+				"reason": reason,
+				"code":   code.Code,
+			})
+		}
+	}
+
+	if wantsProblem(c) {
+		abortError(c, http.StatusUnprocessableEntity, errcode.ValidationFailed, gin.H{"invalid-params": invalidParams})
+		return
+	}
+	abortError(c, http.StatusUnprocessableEntity, errcode.ValidationFailed, gin.H{
+		"errors":      messages,
+		"error_codes": codes,
 	})
 }
 
 // Unauthorized响应401 未传参msg使用默认消息，登录失败，jwt解析失败
 
 func Unauthorized(c *gin.Context, msg ...string) {
-	c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
-		"message": defaultMessage("请求解析错误，请确认请求格式是否正确。", msg...),
-	})
+	abortError(c, http.StatusUnauthorized, errcode.Unauthorized, nil, msg...)
+}
+
+// abortError 响应错误，extra 为附加字段
+// 客户端接受 problem details 时使用该格式，否则使用 code、key、message 格式
+func abortError(c *gin.Context, status int, defaultCode errcode.Code, extra gin.H, msg ...string) {
+	code := resolveCode(status, defaultCode, msg...)
+	message := defaultMessage(c, defaultCode.MessageKey, msg...)
+
+	asProblem := wantsProblem(c)
+	var body gin.H
+	if asProblem {
+		body = problem(c, status, code, message)
+	} else {
+		body = gin.H{
+			"code":    code.Code,
+			"key":     code.Key,
+			"message": message,
+		}
+	}
+	for k, v := range extra {
+		body[k] = v
+	}
+
+	if asProblem {
+		abortWithProblem(c, status, body)
+		return
+	}
+	c.AbortWithStatusJSON(status, body)
+}
+
+// resolveCode msg 为已注册错误码的消息键时，使用该错误码代替 defaultCode，
+// 该错误码的 HTTP 状态码需与调用方指定的 status 一致，如 Abort404 不会返回 422 的错误码
+func resolveCode(status int, defaultCode errcode.Code, msg ...string) errcode.Code {
+	if len(msg) > 0 {
+		if registered, ok := errcode.ByMessageKey(i18n.Key(msg[0])); ok && registered.Status == status {
+			return registered
+		}
+	}
+	return defaultCode
 }
 
-func defaultMessage(defaultMsg string, msg ...string) (message string) {
+// defaultMessage 返回当前请求语言的消息，msg 和 defaultKey 都可以是语言包中的消息键
+func defaultMessage(c *gin.Context, defaultKey string, msg ...string) (message string) {
 	if len(msg) > 0 {
-		message = msg[0]
+		message = i18n.Trans(c, msg[0])
 	} else {
-		message = defaultMsg
+		message = i18n.Trans(c, defaultKey)
 	}
 	return
 }
@@ -0,0 +1,12 @@
+package search
+
+type Driver interface {
+	// 写入或更新文档
+	Index(index Index, doc Document) error
+
+	// 删除文档
+	Delete(index Index, id uint64) error
+
+	// 搜索，返回当页结果和总条数
+	Search(index Index, query string, offset, limit int) ([]Hit, int64, error)
+}
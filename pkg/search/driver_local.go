@@ -0,0 +1,163 @@
+package search
+
+import (
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Local 实现 search.Driver interface，使用内存中的倒排索引
+// 不依赖数据库的全文检索能力，适合 SQLite 部署，启动时通过 Search.Rebuild 加载。
+// 索引只在写入数据的进程内更新，仅支持单实例部署，多实例请使用 SQL 驱动
+type Local struct {
+	mu      sync.RWMutex
+	indexes map[string]*invertedIndex
+}
+
+// invertedIndex 单个索引的倒排表和原文
+// terms 为倒排表中全部词的有序列表，前缀匹配时二分查找起点，无需遍历整个倒排表
+type invertedIndex struct {
+	postings map[string]map[uint64]struct{}
+	terms    []string
+	docs     map[uint64]Document
+}
+
+// NewLocal 创建 Local 驱动
+func NewLocal() *Local {
+	return &Local{indexes: make(map[string]*invertedIndex)}
+}
+
+// Index 实现 search.Driver interface 的 Index 方法
+func (l *Local) Index(index Index, doc Document) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	idx := l.get(index.Name)
+	idx.remove(doc.ID)
+	idx.docs[doc.ID] = doc
+	for _, field := range index.Fields {
+		for _, term := range Tokenize(doc.Fields[field]) {
+			if idx.postings[term] == nil {
+				idx.postings[term] = make(map[uint64]struct{})
+				idx.addTerm(term)
+			}
+			idx.postings[term][doc.ID] = struct{}{}
+		}
+	}
+	return nil
+}
+
+// Delete 实现 search.Driver interface 的 Delete 方法
+func (l *Local) Delete(index Index, id uint64) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.get(index.Name).remove(id)
+	return nil
+}
+
+// Search 实现 search.Driver interface 的 Search 方法
+// 每个关键词按前缀匹配，文档需匹配全部关键词
+func (l *Local) Search(index Index, query string, offset, limit int) ([]Hit, int64, error) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	terms := Tokenize(query)
+	idx, ok := l.indexes[index.Name]
+	if !ok || len(terms) == 0 {
+		return []Hit{}, 0, nil
+	}
+
+	var matched map[uint64]struct{}
+	for _, term := range terms {
+		ids := make(map[uint64]struct{})
+		for i := sort.SearchStrings(idx.terms, term); i < len(idx.terms) && strings.HasPrefix(idx.terms[i], term); i++ {
+			for id := range idx.postings[idx.terms[i]] {
+				if matched == nil {
+					ids[id] = struct{}{}
+				} else if _, ok := matched[id]; ok {
+					ids[id] = struct{}{}
+				}
+			}
+		}
+		matched = ids
+		if len(matched) == 0 {
+			break
+		}
+	}
+
+	// 与 SQL 驱动一致，新数据在前
+	ids := make([]uint64, 0, len(matched))
+	for id := range matched {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] > ids[j] })
+
+	total := int64(len(ids))
+	if offset < 0 {
+		offset = 0
+	}
+	if offset >= len(ids) {
+		return []Hit{}, total, nil
+	}
+	end := offset + limit
+	if limit <= 0 || end > len(ids) {
+		end = len(ids)
+	}
+
+	hits := make([]Hit, 0, end-offset)
+	for _, id := range ids[offset:end] {
+		hits = append(hits, newHit(idx.docs[id], terms))
+	}
+	return hits, total, nil
+}
+
+// get 获取索引，不存在时创建，调用方需持有写锁
+func (l *Local) get(name string) *invertedIndex {
+	idx, ok := l.indexes[name]
+	if !ok {
+		idx = &invertedIndex{
+			postings: make(map[string]map[uint64]struct{}),
+			docs:     make(map[uint64]Document),
+		}
+		l.indexes[name] = idx
+	}
+	return idx
+}
+
+// remove 从倒排表中移除文档
+func (idx *invertedIndex) remove(id uint64) {
+	doc, ok := idx.docs[id]
+	if !ok {
+		return
+	}
+	for _, value := range doc.Fields {
+		for _, term := range Tokenize(value) {
+			postings, ok := idx.postings[term]
+			if !ok {
+				continue
+			}
+			delete(postings, id)
+			if len(postings) == 0 {
+				delete(idx.postings, term)
+				idx.removeTerm(term)
+			}
+		}
+	}
+	delete(idx.docs, id)
+}
+
+// addTerm 将新词插入有序列表
+func (idx *invertedIndex) addTerm(term string) {
+	i := sort.SearchStrings(idx.terms, term)
+	idx.terms = append(idx.terms, "")
+	copy(idx.terms[i+1:], idx.terms[i:])
+	idx.terms[i] = term
+}
+
+// removeTerm 从有序列表中移除词
+func (idx *invertedIndex) removeTerm(term string) {
+	i := sort.SearchStrings(idx.terms, term)
+	if i < len(idx.terms) && idx.terms[i] == term {
+		idx.terms = append(idx.terms[:i], idx.terms[i+1:]...)
+	}
+}
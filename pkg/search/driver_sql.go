@@ -0,0 +1,71 @@
+package search
+
+import (
+	"strings"
+
+	"gohub/pkg/config"
+	"gohub/pkg/database"
+	"gorm.io/gorm"
+)
+
+// SQL 实现 search.Driver interface，直接查询数据表
+// MySQL 开启 search.mysql_fulltext 时使用 FULLTEXT 索引，否则使用 LIKE
+type SQL struct{}
+
+// Index 实现 search.Driver interface 的 Index 方法，数据表即索引，无需处理
+func (s *SQL) Index(index Index, doc Document) error {
+	return nil
+}
+
+// Delete 实现 search.Driver interface 的 Delete 方法，数据表即索引，无需处理
+func (s *SQL) Delete(index Index, id uint64) error {
+	return nil
+}
+
+// Search 实现 search.Driver interface 的 Search 方法
+func (s *SQL) Search(index Index, query string, offset, limit int) ([]Hit, int64, error) {
+	db := database.DB.Table(index.Table)
+	if config.Get("database.connection") == "mysql" && config.GetBool("search.mysql_fulltext") {
+		db = db.Where("MATCH("+strings.Join(index.Fields, ",")+") AGAINST(? IN NATURAL LANGUAGE MODE)", query)
+	} else {
+		like := "%" + escapeLike(query) + "%"
+		conditions := make([]string, len(index.Fields))
+		args := make([]interface{}, len(index.Fields))
+		for i, field := range index.Fields {
+			conditions[i] = field + " LIKE ? ESCAPE '!'"
+			args[i] = like
+		}
+		db = db.Where(strings.Join(conditions, " OR "), args...)
+	}
+
+	// 使用新的 Session，保证 Count 和 Find 互不影响
+	db = db.Session(&gorm.Session{})
+
+	var total int64
+	if err := db.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var rows []map[string]interface{}
+	err := db.Select(append([]string{"id"}, index.Fields...)).
+		Order("id desc").
+		Offset(offset).
+		Limit(limit).
+		Find(&rows).
+		Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	terms := Tokenize(query)
+	hits := make([]Hit, 0, len(rows))
+	for _, row := range rows {
+		hits = append(hits, newHit(documentFromRow(index, row), terms))
+	}
+	return hits, total, nil
+}
+
+// escapeLike 转义 LIKE 通配符，配合 ESCAPE '!' 使用
+func escapeLike(s string) string {
+	return strings.NewReplacer("!", "!!", "%", "!%", "_", "!_").Replace(s)
+}
@@ -0,0 +1,124 @@
+package search
+
+import (
+	"html"
+	"strings"
+	"unicode"
+
+	"github.com/spf13/cast"
+	"gohub/pkg/config"
+)
+
+// Tokenize 分词：英文和数字按单词切分，中文按单字切分，统一转小写
+func Tokenize(text string) []string {
+	var terms []string
+	var word []rune
+	flush := func() {
+		if len(word) > 0 {
+			terms = append(terms, string(word))
+			word = word[:0]
+		}
+	}
+	for _, r := range strings.ToLower(text) {
+		switch {
+		case unicode.Is(unicode.Han, r):
+			flush()
+			terms = append(terms, string(r))
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			word = append(word, r)
+		default:
+			flush()
+		}
+	}
+	flush()
+	return terms
+}
+
+// Highlight 截取关键词附近的片段，HTML 转义后使用 <em> 标记关键词
+// 未命中关键词时返回空字符串
+func Highlight(text string, terms []string) string {
+	runes := []rune(text)
+	lower := []rune(strings.ToLower(text))
+	// 个别字符转小写后长度会变化，此时放弃大小写不敏感匹配
+	if len(lower) != len(runes) {
+		lower = runes
+	}
+
+	// 标记命中关键词的字符
+	marked := make([]bool, len(runes))
+	first := -1
+	for _, term := range terms {
+		t := []rune(term)
+		if len(t) == 0 {
+			continue
+		}
+		for i := 0; i+len(t) <= len(lower); i++ {
+			if string(lower[i:i+len(t)]) != term {
+				continue
+			}
+			for j := i; j < i+len(t); j++ {
+				marked[j] = true
+			}
+			if first == -1 || i < first {
+				first = i
+			}
+		}
+	}
+	if first == -1 {
+		return ""
+	}
+
+	// 以第一个命中位置为中心截取片段
+	radius := config.GetInt("search.snippet_radius")
+	if radius <= 0 {
+		radius = len(runes)
+	}
+	start, end := first-radius, first+radius
+	if start < 0 {
+		start = 0
+	}
+	if end > len(runes) {
+		end = len(runes)
+	}
+
+	var b strings.Builder
+	if start > 0 {
+		b.WriteString("...")
+	}
+	for i := start; i < end; i++ {
+		if marked[i] && (i == start || !marked[i-1]) {
+			b.WriteString("<em>")
+		}
+		b.WriteString(html.EscapeString(string(runes[i])))
+		if marked[i] && (i == end-1 || !marked[i+1]) {
+			b.WriteString("</em>")
+		}
+	}
+	if end < len(runes) {
+		b.WriteString("...")
+	}
+	return b.String()
+}
+
+// newHit 生成搜索结果，只返回命中关键词的字段
+func newHit(doc Document, terms []string) Hit {
+	hit := Hit{ID: doc.ID, Highlights: make(map[string]string)}
+	for field, value := range doc.Fields {
+		if snippet := Highlight(value, terms); snippet != "" {
+			hit.Highlights[field] = snippet
+		}
+	}
+	return hit
+}
+
+// documentFromRow 将数据表的一行转换为 Document
+func documentFromRow(index Index, row map[string]interface{}) Document {
+	doc := Document{
+		ID:     cast.ToUint64(row["id"]),
+		Fields: make(map[string]string, len(index.Fields)),
+	}
+	for _, field := range index.Fields {
+		doc.Fields[field] = cast.ToString(row[field])
+	}
+	return doc
+}
@@ -0,0 +1,131 @@
+// Package search 处理全文搜索逻辑
+package search
+
+import (
+	"errors"
+	"sync"
+
+	"gohub/pkg/config"
+	"gohub/pkg/database"
+	"gohub/pkg/logger"
+)
+
+// Index 可搜索的数据表
+type Index struct {
+	Name   string   // 索引名称，如 users
+	Table  string   // 数据表
+	Fields []string // 参与搜索的字段
+}
+
+// Document 待索引的文档
+type Document struct {
+	ID     uint64
+	Fields map[string]string
+}
+
+// Hit 一条搜索结果
+type Hit struct {
+	ID         uint64            `json:"id"`
+	Highlights map[string]string `json:"highlights"`
+}
+
+// Search 搜索操作类
+type Search struct {
+	Driver Driver
+
+	mu      sync.RWMutex
+	indexes map[string]Index
+}
+
+// once 单例模式
+var once sync.Once
+
+// internalSearch 内部使用的 Search 对象
+var internalSearch *Search
+
+// NewSearch 单例模式获取
+func NewSearch() *Search {
+	once.Do(func() {
+		var driver Driver
+		switch config.Get("search.driver") {
+		case "local":
+			driver = NewLocal()
+		default:
+			driver = &SQL{}
+		}
+		internalSearch = &Search{
+			Driver:  driver,
+			indexes: make(map[string]Index),
+		}
+	})
+	return internalSearch
+}
+
+// Register 注册可搜索的数据表
+func (s *Search) Register(index Index) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.indexes[index.Name] = index
+}
+
+// Index 写入或更新文档
+func (s *Search) Index(name string, doc Document) error {
+	index, err := s.index(name)
+	if err != nil {
+		return err
+	}
+	return s.Driver.Index(index, doc)
+}
+
+// Delete 删除文档
+func (s *Search) Delete(name string, id uint64) error {
+	index, err := s.index(name)
+	if err != nil {
+		return err
+	}
+	return s.Driver.Delete(index, id)
+}
+
+// Search 搜索，返回当页结果和总条数
+func (s *Search) Search(name string, query string, offset, limit int) ([]Hit, int64, error) {
+	index, err := s.index(name)
+	if err != nil {
+		return nil, 0, err
+	}
+	return s.Driver.Search(index, query, offset, limit)
+}
+
+// Rebuild 从数据表读取全部数据重建索引，local 驱动启动时调用
+func (s *Search) Rebuild(name string) error {
+	index, err := s.index(name)
+	if err != nil {
+		return err
+	}
+
+	rows, err := database.DB.Table(index.Table).
+		Select(append([]string{"id"}, index.Fields...)).
+		Rows()
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		row := map[string]interface{}{}
+		if err := database.DB.ScanRows(rows, &row); err != nil {
+			return err
+		}
+		logger.LogIf(s.Driver.Index(index, documentFromRow(index, row)))
+	}
+	return rows.Err()
+}
+
+func (s *Search) index(name string) (Index, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	index, ok := s.indexes[name]
+	if !ok {
+		return Index{}, errors.New("search index not registered: " + name)
+	}
+	return index, nil
+}
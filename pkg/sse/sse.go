@@ -0,0 +1,102 @@
+// Package sse 使用 Server-Sent Events 推送事件，作为 WebSocket 的替代方案
+package sse
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+	"time"
+
+	"github.com/gin-contrib/sse"
+	"github.com/gin-gonic/gin"
+	"github.com/spf13/cast"
+	"gohub/pkg/config"
+	"gohub/pkg/errcode"
+	"gohub/pkg/logger"
+	"gohub/pkg/redis"
+	"gohub/pkg/response"
+)
+
+// eventIDPattern Redis stream 的消息 ID 格式，客户端传入的续传 ID 必须符合
+var eventIDPattern = regexp.MustCompile(`^\d+-\d+$`)
+
+// Publish 追加事件到用户的 stream，返回事件 ID，失败时返回空字符串
+func Publish(userID uint64, event string, data interface{}) string {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		logger.LogIf(err)
+		return ""
+	}
+	return redis.Redis.XAdd(streamKey(userID), config.GetInt64("sse.max_len"), map[string]interface{}{
+		"event": event,
+		"data":  string(payload),
+	})
+}
+
+// Serve 以 SSE 方式持续推送用户的事件，直到客户端断开
+// 支持 Last-Event-ID 请求头（或 last_event_id 参数）断线续传，值为事件 ID 或 $（只推送新事件）
+// 调用方需先完成身份认证，确定 userID
+func Serve(c *gin.Context, userID uint64) {
+	key := streamKey(userID)
+
+	lastID := c.GetHeader("Last-Event-ID")
+	if len(lastID) == 0 {
+		lastID = c.Query("last_event_id")
+	}
+	if len(lastID) > 0 && lastID != "$" && !eventIDPattern.MatchString(lastID) {
+		response.Abort(c, errcode.BadRequest, "sse.last_event_id_invalid")
+		return
+	}
+	if len(lastID) == 0 || lastID == "$" {
+		// 没有续传 ID，只推送连接之后的新事件
+		// 不直接使用 $，否则两次 XREAD 之间写入的事件会丢失
+		lastID = redis.Redis.XLastID(key)
+	}
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	// 关闭 Nginx 的响应缓冲
+	c.Header("X-Accel-Buffering", "no")
+
+	ctx := c.Request.Context()
+	heartbeat := time.Duration(config.GetInt("sse.heartbeat")) * time.Second
+
+	c.Stream(func(w io.Writer) bool {
+		messages, err := redis.Redis.XRead(ctx, key, lastID, 100, heartbeat)
+		if ctx.Err() != nil {
+			return false
+		}
+
+		// Redis 出错时结束连接，客户端在 retry 毫秒后带上 Last-Event-ID 重连
+		if err != nil {
+			c.Render(-1, sse.Event{
+				Retry: uint(config.GetInt("sse.retry")),
+				Event: "reconnect",
+				Data:  "",
+			})
+			return false
+		}
+
+		// 超时无事件，发送注释行作为心跳
+		if len(messages) == 0 {
+			_, err := fmt.Fprint(w, ": heartbeat\n\n")
+			return err == nil
+		}
+
+		for _, message := range messages {
+			c.Render(-1, sse.Event{
+				Id:    message.ID,
+				Event: cast.ToString(message.Values["event"]),
+				Data:  cast.ToString(message.Values["data"]),
+			})
+			lastID = message.ID
+		}
+		return true
+	})
+}
+
+func streamKey(userID uint64) string {
+	return config.GetString("app.name") + ":events:" + cast.ToString(userID)
+}
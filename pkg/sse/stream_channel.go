@@ -0,0 +1,22 @@
+package sse
+
+import (
+	"errors"
+
+	"gohub/pkg/notify"
+)
+
+// StreamChannel 实现 notify.Channel interface，将通知写入用户的事件 stream
+type StreamChannel struct{}
+
+// Send 实现 notify.Channel interface 的 Send 方法
+func (ch *StreamChannel) Send(n notify.Notification) error {
+	id := Publish(n.UserID, "notification", map[string]interface{}{
+		"type": n.Type,
+		"data": n.Data,
+	})
+	if len(id) == 0 {
+		return errors.New("sse: 写入事件 stream 失败")
+	}
+	return nil
+}
@@ -0,0 +1,127 @@
+package tracing
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// newExporter 根据配置创建 exporter
+func newExporter(opts Options) (sdktrace.SpanExporter, error) {
+	switch opts.Exporter {
+	case "stdout":
+		return &writerExporter{w: os.Stdout}, nil
+	case "file":
+		if err := os.MkdirAll(filepath.Dir(opts.FilePath), os.ModePerm); err != nil {
+			return nil, err
+		}
+		f, err := os.OpenFile(opts.FilePath, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+		if err != nil {
+			return nil, err
+		}
+		return &writerExporter{w: f, closer: f}, nil
+	case "otlp":
+		return newOTLPExporter(opts.Endpoint, opts.Headers), nil
+	default:
+		return nil, fmt.Errorf("tracing exporter %q not supported", opts.Exporter)
+	}
+}
+
+// writerExporter 每个 span 输出一行 JSON，不依赖外部服务，离线也能查看
+type writerExporter struct {
+	mu     sync.Mutex
+	w      io.Writer
+	closer io.Closer
+}
+
+// spanRecord 单个 span 输出的内容
+type spanRecord struct {
+	TraceID      string                 `json:"trace_id"`
+	SpanID       string                 `json:"span_id"`
+	ParentSpanID string                 `json:"parent_span_id,omitempty"`
+	Name         string                 `json:"name"`
+	Kind         string                 `json:"kind"`
+	Start        time.Time              `json:"start"`
+	Duration     string                 `json:"duration"`
+	Status       string                 `json:"status"`
+	StatusText   string                 `json:"status_text,omitempty"`
+	Attributes   map[string]interface{} `json:"attributes,omitempty"`
+	Events       []eventRecord          `json:"events,omitempty"`
+}
+
+type eventRecord struct {
+	Name       string                 `json:"name"`
+	Time       time.Time              `json:"time"`
+	Attributes map[string]interface{} `json:"attributes,omitempty"`
+}
+
+// ExportSpans 实现 sdktrace.SpanExporter
+func (e *writerExporter) ExportSpans(ctx context.Context, spans []sdktrace.ReadOnlySpan) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	encoder := json.NewEncoder(e.w)
+	for _, span := range spans {
+		record := spanRecord{
+			TraceID:    span.SpanContext().TraceID().String(),
+			SpanID:     span.SpanContext().SpanID().String(),
+			Name:       span.Name(),
+			Kind:       span.SpanKind().String(),
+			Start:      span.StartTime(),
+			Duration:   span.EndTime().Sub(span.StartTime()).String(),
+			Status:     span.Status().Code.String(),
+			StatusText: span.Status().Description,
+			Attributes: attributeMap(span.Attributes()),
+		}
+		if span.Parent().IsValid() {
+			record.ParentSpanID = span.Parent().SpanID().String()
+		}
+		for _, event := range span.Events() {
+			record.Events = append(record.Events, eventRecord{
+				Name:       event.Name,
+				Time:       event.Time,
+				Attributes: attributeMap(event.Attributes),
+			})
+		}
+		if err := encoder.Encode(record); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Shutdown 实现 sdktrace.SpanExporter，关闭写入的文件
+func (e *writerExporter) Shutdown(ctx context.Context) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.closer == nil {
+		return nil
+	}
+	err := e.closer.Close()
+	e.closer = nil
+	if errors.Is(err, os.ErrClosed) {
+		return nil
+	}
+	return err
+}
+
+func attributeMap(attrs []attribute.KeyValue) map[string]interface{} {
+	if len(attrs) == 0 {
+		return nil
+	}
+	m := make(map[string]interface{}, len(attrs))
+	for _, attr := range attrs {
+		m[string(attr.Key)] = attr.Value.AsInterface()
+	}
+	return m
+}
@@ -0,0 +1,172 @@
+package tracing
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/sdk/instrumentation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// otlpExporter 使用 OTLP/HTTP 的 JSON 编码上报 span，
+// Jaeger、Tempo、OpenTelemetry Collector 等均支持该协议
+type otlpExporter struct {
+	endpoint string
+	headers  map[string]string
+	client   *http.Client
+}
+
+func newOTLPExporter(endpoint string, headers map[string]string) *otlpExporter {
+	return &otlpExporter{
+		endpoint: endpoint,
+		headers:  headers,
+		client:   &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// ExportSpans 实现 sdktrace.SpanExporter
+func (e *otlpExporter) ExportSpans(ctx context.Context, spans []sdktrace.ReadOnlySpan) error {
+	if len(spans) == 0 {
+		return nil
+	}
+	body, err := json.Marshal(otlpRequest(spans))
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for key, value := range e.headers {
+		req.Header.Set(key, value)
+	}
+
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("otlp export failed: %s %s", resp.Status, msg)
+	}
+	return nil
+}
+
+// Shutdown 实现 sdktrace.SpanExporter
+func (e *otlpExporter) Shutdown(ctx context.Context) error {
+	e.client.CloseIdleConnections()
+	return nil
+}
+
+// otlpRequest 按 OTLP JSON 的结构组装 ExportTraceServiceRequest，
+// 同一进程的 span 共用一个 resource，按 instrumentation scope 分组
+func otlpRequest(spans []sdktrace.ReadOnlySpan) map[string]interface{} {
+	var scopes []instrumentation.Scope
+	grouped := make(map[instrumentation.Scope][]map[string]interface{})
+	for _, span := range spans {
+		scope := span.InstrumentationScope()
+		if _, ok := grouped[scope]; !ok {
+			scopes = append(scopes, scope)
+		}
+		grouped[scope] = append(grouped[scope], otlpSpan(span))
+	}
+
+	scopeSpans := make([]map[string]interface{}, 0, len(scopes))
+	for _, scope := range scopes {
+		scopeSpans = append(scopeSpans, map[string]interface{}{
+			"scope": map[string]interface{}{"name": scope.Name, "version": scope.Version},
+			"spans": grouped[scope],
+		})
+	}
+
+	resourceSpans := map[string]interface{}{"scopeSpans": scopeSpans}
+	if res := spans[0].Resource(); res != nil {
+		resourceSpans["resource"] = map[string]interface{}{"attributes": otlpAttributes(res.Attributes())}
+		resourceSpans["schemaUrl"] = res.SchemaURL()
+	}
+	return map[string]interface{}{
+		"resourceSpans": []interface{}{resourceSpans},
+	}
+}
+
+func otlpSpan(span sdktrace.ReadOnlySpan) map[string]interface{} {
+	s := map[string]interface{}{
+		"traceId":           span.SpanContext().TraceID().String(),
+		"spanId":            span.SpanContext().SpanID().String(),
+		"name":              span.Name(),
+		"kind":              int(span.SpanKind()),
+		"startTimeUnixNano": unixNano(span.StartTime()),
+		"endTimeUnixNano":   unixNano(span.EndTime()),
+		"attributes":        otlpAttributes(span.Attributes()),
+		"status":            otlpStatus(span.Status()),
+	}
+	if span.Parent().IsValid() {
+		s["parentSpanId"] = span.Parent().SpanID().String()
+	}
+
+	events := make([]map[string]interface{}, 0, len(span.Events()))
+	for _, event := range span.Events() {
+		events = append(events, map[string]interface{}{
+			"name":         event.Name,
+			"timeUnixNano": unixNano(event.Time),
+			"attributes":   otlpAttributes(event.Attributes),
+		})
+	}
+	s["events"] = events
+	return s
+}
+
+// otlpStatus OTLP 的状态码与 otel-go 的顺序不同：OK 为 1，Error 为 2
+func otlpStatus(status sdktrace.Status) map[string]interface{} {
+	code := 0
+	switch status.Code {
+	case codes.Ok:
+		code = 1
+	case codes.Error:
+		code = 2
+	}
+	return map[string]interface{}{"code": code, "message": status.Description}
+}
+
+func otlpAttributes(attrs []attribute.KeyValue) []map[string]interface{} {
+	result := make([]map[string]interface{}, 0, len(attrs))
+	for _, attr := range attrs {
+		result = append(result, map[string]interface{}{
+			"key":   string(attr.Key),
+			"value": otlpValue(attr.Value),
+		})
+	}
+	return result
+}
+
+// otlpValue 按 OTLP JSON 的 AnyValue 编码，int64 按 proto3 JSON 的约定使用字符串
+func otlpValue(v attribute.Value) map[string]interface{} {
+	switch v.Type() {
+	case attribute.BOOL:
+		return map[string]interface{}{"boolValue": v.AsBool()}
+	case attribute.INT64:
+		return map[string]interface{}{"intValue": strconv.FormatInt(v.AsInt64(), 10)}
+	case attribute.FLOAT64:
+		return map[string]interface{}{"doubleValue": v.AsFloat64()}
+	case attribute.STRING:
+		return map[string]interface{}{"stringValue": v.AsString()}
+	default:
+		// 数组类型统一转成字符串，避免逐一处理各种切片
+		return map[string]interface{}{"stringValue": v.Emit()}
+	}
+}
+
+func unixNano(t time.Time) string {
+	return strconv.FormatInt(t.UnixNano(), 10)
+}
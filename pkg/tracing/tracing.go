@@ -0,0 +1,76 @@
+// Package tracing 基于 OpenTelemetry 的分布式追踪
+package tracing
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.12.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// instrumentationName 本项目创建的 span 所属的 instrumentation scope
+const instrumentationName = "gohub"
+
+// provider 未初始化时为 nil，此时全局使用 otel 默认的 noop 实现
+var provider *sdktrace.TracerProvider
+
+// Options 追踪配置
+type Options struct {
+	// ServiceName 上报的服务名称
+	ServiceName string
+	// Exporter 导出方式：stdout、file、otlp
+	Exporter string
+	// FilePath Exporter 为 file 时写入的文件
+	FilePath string
+	// Endpoint Exporter 为 otlp 时的 OTLP/HTTP 地址，如 http://localhost:4318/v1/traces
+	Endpoint string
+	// Headers 请求 OTLP 时附带的请求头，如鉴权信息
+	Headers map[string]string
+	// SampleRatio 采样比例，0 到 1，上游已决定采样的请求沿用上游的决定
+	SampleRatio float64
+}
+
+// Init 初始化全局 TracerProvider，并使用 W3C traceparent 在服务间传播
+func Init(opts Options) error {
+	exporter, err := newExporter(opts)
+	if err != nil {
+		return err
+	}
+
+	provider = sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(resource.NewWithAttributes(
+			semconv.SchemaURL,
+			semconv.ServiceNameKey.String(opts.ServiceName),
+		)),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(opts.SampleRatio))),
+	)
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+	return nil
+}
+
+// Enabled 是否已开启追踪
+func Enabled() bool {
+	return provider != nil
+}
+
+// Tracer 获取本项目使用的 Tracer
+func Tracer() trace.Tracer {
+	return otel.Tracer(instrumentationName)
+}
+
+// Shutdown 导出缓冲中的 span 并关闭 exporter，程序退出前调用
+func Shutdown(ctx context.Context) error {
+	if provider == nil {
+		return nil
+	}
+	return provider.Shutdown(ctx)
+}
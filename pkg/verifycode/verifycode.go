@@ -67,7 +67,7 @@ func (vc *VerifyCode) generateVerifyCode(key string) string {
 
 	// 方便调试，本地使用固定的验证码
 	if app.IsLocal() {
-		code = config.GetString("verifycode_debug_code")
+		code = config.GetString("verifycode.debug_code")
 	}
 	logger.DebugJSON("验证码", "生成验证码", map[string]string{key: code})
 	// 将验证码 及 KEY存储到 redis 中，并设置过期时间
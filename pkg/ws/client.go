@@ -0,0 +1,102 @@
+package ws
+
+import (
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+)
+
+const (
+	// 写消息超时
+	writeWait = 10 * time.Second
+	// 等待客户端 pong 的时间
+	pongWait = 60 * time.Second
+	// 发送 ping 的间隔，必须小于 pongWait
+	pingPeriod = (pongWait * 9) / 10
+	// 客户端消息最大长度，服务端只推送，不接收业务消息
+	maxMessageSize = 512
+)
+
+var upgrader = websocket.Upgrader{
+	ReadBufferSize:  1024,
+	WriteBufferSize: 1024,
+}
+
+// Client 一个用户的 WebSocket 连接
+type Client struct {
+	UserID uint64
+
+	hub  *Hub
+	conn *websocket.Conn
+	send chan []byte
+}
+
+// Serve 将请求升级为 WebSocket 连接，并登记到 Hub
+// 调用方需先完成身份认证，确定 userID
+func Serve(c *gin.Context, userID uint64) error {
+	conn, err := upgrader.Upgrade(c.Writer, c.Request, nil)
+	if err != nil {
+		return err
+	}
+
+	client := &Client{
+		UserID: userID,
+		hub:    NewHub(),
+		conn:   conn,
+		send:   make(chan []byte, 256),
+	}
+	client.hub.Register(client)
+
+	go client.writePump()
+	go client.readPump()
+	return nil
+}
+
+// readPump 读取客户端消息，用于处理 pong 和检测连接断开
+func (c *Client) readPump() {
+	defer func() {
+		c.hub.Unregister(c)
+		c.conn.Close()
+	}()
+
+	c.conn.SetReadLimit(maxMessageSize)
+	c.conn.SetReadDeadline(time.Now().Add(pongWait))
+	c.conn.SetPongHandler(func(string) error {
+		return c.conn.SetReadDeadline(time.Now().Add(pongWait))
+	})
+	for {
+		if _, _, err := c.conn.ReadMessage(); err != nil {
+			return
+		}
+	}
+}
+
+// writePump 将发送队列中的消息写入连接，并定时发送 ping
+func (c *Client) writePump() {
+	ticker := time.NewTicker(pingPeriod)
+	defer func() {
+		ticker.Stop()
+		c.conn.Close()
+	}()
+
+	for {
+		select {
+		case message, ok := <-c.send:
+			c.conn.SetWriteDeadline(time.Now().Add(writeWait))
+			if !ok {
+				// Hub 已关闭发送队列
+				c.conn.WriteMessage(websocket.CloseMessage, []byte{})
+				return
+			}
+			if err := c.conn.WriteMessage(websocket.TextMessage, message); err != nil {
+				return
+			}
+		case <-ticker.C:
+			c.conn.SetWriteDeadline(time.Now().Add(writeWait))
+			if err := c.conn.WriteMessage(websocket.PingMessage, nil); err != nil {
+				return
+			}
+		}
+	}
+}
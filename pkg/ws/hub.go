@@ -0,0 +1,89 @@
+// Package ws 处理 WebSocket 连接和消息推送
+package ws
+
+import (
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// Hub 维护本实例上所有用户的 WebSocket 连接
+type Hub struct {
+	mu      sync.RWMutex
+	clients map[uint64]map[*Client]bool
+}
+
+// once 单例模式
+var once sync.Once
+
+// internalHub 内部使用的 Hub 对象
+var internalHub *Hub
+
+// NewHub 单例模式获取
+func NewHub() *Hub {
+	once.Do(func() {
+		internalHub = &Hub{
+			clients: make(map[uint64]map[*Client]bool),
+		}
+	})
+	return internalHub
+}
+
+// Register 登记连接，同一用户可以有多个连接
+func (h *Hub) Register(client *Client) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.clients[client.UserID] == nil {
+		h.clients[client.UserID] = make(map[*Client]bool)
+	}
+	h.clients[client.UserID][client] = true
+}
+
+// Unregister 注销连接并关闭发送队列
+func (h *Hub) Unregister(client *Client) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if _, ok := h.clients[client.UserID][client]; !ok {
+		return
+	}
+	delete(h.clients[client.UserID], client)
+	if len(h.clients[client.UserID]) == 0 {
+		delete(h.clients, client.UserID)
+	}
+	close(client.send)
+}
+
+// SendToUser 推送消息到用户在本实例上的所有连接
+func (h *Hub) SendToUser(userID uint64, message []byte) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	for client := range h.clients[userID] {
+		select {
+		case client.send <- message:
+		default:
+			// 发送队列已满，说明客户端消费过慢，丢弃此条消息
+		}
+	}
+}
+
+// Online 用户在本实例上的连接数
+func (h *Hub) Online(userID uint64) int {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return len(h.clients[userID])
+}
+
+// CloseAll 通知并关闭本实例上的所有连接，服务关闭时调用，客户端收到 1001 后可重连其他实例
+func (h *Hub) CloseAll() {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	message := websocket.FormatCloseMessage(websocket.CloseGoingAway, "server shutdown")
+	for _, clients := range h.clients {
+		for client := range clients {
+			// WriteControl 和 Close 可以与 writePump 并发调用
+			client.conn.WriteControl(websocket.CloseMessage, message, time.Now().Add(writeWait))
+			client.conn.Close()
+		}
+	}
+}
@@ -0,0 +1,58 @@
+package ws
+
+import (
+	"encoding/json"
+	"errors"
+
+	"gohub/pkg/config"
+	"gohub/pkg/logger"
+	"gohub/pkg/notify"
+	"gohub/pkg/redis"
+)
+
+// message 通过 Redis pub/sub 在实例间传递的消息
+type message struct {
+	UserID uint64                 `json:"user_id"`
+	Type   string                 `json:"type"`
+	Data   map[string]interface{} `json:"data"`
+}
+
+// RedisChannel 实现 notify.Channel interface
+// 将通知发布到 Redis，由所有实例的 Subscribe 推送给本地连接
+type RedisChannel struct{}
+
+// Send 实现 notify.Channel interface 的 Send 方法
+func (ch *RedisChannel) Send(n notify.Notification) error {
+	payload, err := json.Marshal(message{
+		UserID: n.UserID,
+		Type:   n.Type,
+		Data:   n.Data,
+	})
+	if err != nil {
+		return err
+	}
+	if ok := redis.Redis.Publish(pubsubChannel(), payload); !ok {
+		return errors.New("ws: 发布通知到 Redis 失败")
+	}
+	return nil
+}
+
+// Subscribe 订阅 Redis 通知消息，推送给本实例上对应用户的连接，阻塞运行
+func Subscribe() {
+	pubsub := redis.Redis.Subscribe(pubsubChannel())
+	defer pubsub.Close()
+
+	hub := NewHub()
+	for msg := range pubsub.Channel() {
+		var m message
+		if err := json.Unmarshal([]byte(msg.Payload), &m); err != nil {
+			logger.LogIf(err)
+			continue
+		}
+		hub.SendToUser(m.UserID, []byte(msg.Payload))
+	}
+}
+
+func pubsubChannel() string {
+	return config.GetString("app.name") + ":notifications:pubsub"
+}
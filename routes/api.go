@@ -2,11 +2,26 @@ package routes
 
 import (
 	"github.com/gin-gonic/gin"
+	controllers "gohub/app/http/controllers/api/v1"
 	"gohub/app/http/controllers/api/v1/auth"
+	"gohub/app/http/middlewares"
+	"gohub/app/models/follow"
+	"gohub/app/models/tag"
 )
 
 func RegisterAPIRoutes(r *gin.Engine) {
+	// 存活与就绪探针，不带版本前缀
+	hc := new(controllers.HealthController)
+	r.GET("/healthz", hc.Liveness)
+	r.GET("/readyz", hc.Readiness)
+
 	v1 := r.Group("/v1")
+	v1.Use(
+		// 带 Idempotency-Key 的 POST 请求只处理一次，重试时重放首次的响应
+		middlewares.Idempotency(),
+		// GET 请求的 JSON 响应带 ETag，支持 If-None-Match 条件请求
+		middlewares.ETag(),
+	)
 	{
 		authGroup := v1.Group("/auth")
 		{
@@ -15,9 +30,80 @@ func RegisterAPIRoutes(r *gin.Engine) {
 			authGroup.POST("/signup/phone/exist", suc.IsPhoneExist)
 			// 判断Email是否已注册
 			authGroup.POST("/signup/email/exist", suc.IsEmailExist)
+			// 使用手机号注册
+			authGroup.POST("/signup/using-phone", suc.SignupUsingPhone)
 			// 发送验证码
 			vcc := new(auth.VerifyCodeController)
 			authGroup.POST("/verify_codes/captcha", vcc.ShowCaptcha)
+			// 发送短信验证码
+			authGroup.POST("/verify_codes/phone", vcc.SendUsingPhone)
+			// 使用手机号、Email 或用户名和密码登录
+			lgc := new(auth.LoginController)
+			authGroup.POST("/login/using-password", lgc.LoginByPassword)
+		}
+
+		uc := new(controllers.UsersController)
+		usersGroup := v1.Group("/users")
+		{
+			// 粉丝列表
+			usersGroup.GET("/:id/followers", middlewares.CacheResponse(follow.CacheTag("{id}")), uc.Followers)
+			// 关注列表
+			usersGroup.GET("/:id/followings", middlewares.CacheResponse(follow.CacheTag("{id}")), uc.Followings)
+			// 关注
+			usersGroup.POST("/:id/follow", middlewares.AuthJWT(), uc.Follow)
+			// 取消关注
+			usersGroup.DELETE("/:id/follow", middlewares.AuthJWT(), uc.Unfollow)
+		}
+
+		tpc := new(controllers.TopicsController)
+		topicsGroup := v1.Group("/topics")
+		{
+			// 话题详情
+			topicsGroup.GET("/:id", tpc.Show)
+			// 发布话题
+			topicsGroup.POST("", middlewares.AuthJWT(), tpc.Store)
+			// 更新话题
+			topicsGroup.PUT("/:id", middlewares.AuthJWT(), tpc.Update)
+		}
+
+		ntc := new(controllers.NotificationsController)
+		notificationsGroup := v1.Group("/notifications", middlewares.AuthJWT())
+		{
+			// 通知列表
+			notificationsGroup.GET("", ntc.Index)
+			// 未读通知数
+			notificationsGroup.GET("/unread-count", ntc.UnreadCount)
+			// 全部标记为已读
+			notificationsGroup.POST("/read-all", ntc.MarkAllRead)
+			// 标记为已读
+			notificationsGroup.POST("/:id/read", ntc.MarkRead)
+		}
+
+		rtc := new(controllers.RealtimeController)
+		// WebSocket 推送通知，浏览器无法设置请求头时使用 ?token= 认证
+		v1.GET("/ws", middlewares.Streaming(), middlewares.AuthJWT(), rtc.WebSocket)
+		// SSE 推送通知和话题动态，EventSource 无法设置请求头，同样支持 ?token= 认证
+		v1.GET("/events", middlewares.Streaming(), middlewares.AuthJWT(), rtc.Events)
+
+		// 关注的人最近发布的话题
+		fdc := new(controllers.FeedController)
+		v1.GET("/feed", middlewares.AuthJWT(), fdc.Index)
+
+		// 搜索
+		sc := new(controllers.SearchController)
+		v1.GET("/search", sc.Search)
+
+		// 错误码列表
+		ecc := new(controllers.ErrorCodesController)
+		v1.GET("/error-codes", ecc.Index)
+
+		tgc := new(controllers.TagsController)
+		tagsGroup := v1.Group("/tags")
+		{
+			// 标签自动补全
+			tagsGroup.GET("", middlewares.CacheResponse(tag.CacheTag), tgc.Index)
+			// 标签下的话题
+			tagsGroup.GET("/:slug/topics", tgc.Topics)
 		}
 	}
 }